@@ -0,0 +1,138 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// ErrInvalidId is matched by errors.Is when an id is not a positive integer or
+// the API returned no quote for it, usually because the id does not exist.
+var ErrInvalidId = errors.New("coinmarketcap: invalid id")
+
+// QuoterConfig configures a Quoter.
+type QuoterConfig struct {
+	//How long lookups are collected before they are sent. Default: 10ms.
+	Window time.Duration
+
+	//Maximum number of ids per call; a batch is sent early once it is full. Default: 100.
+	MaxIds int
+
+	//Template for the batched requests. Its convert and aux fields
+	//are used, the ids are set by the Quoter.
+	Request types.CryptocurrencyQuotesLatestRequest
+}
+
+// Quoter merges concurrent single-id quote lookups into one
+// CryptocurrencyQuotesLatest call per batch.
+type Quoter struct {
+	client *CoinmarketcapClient
+	config QuoterConfig
+
+	mu    sync.Mutex
+	batch *quoteBatch
+}
+
+type quoteResult struct {
+	quote types.CryptocurrencyQuote
+	err   error
+}
+
+type quoteBatch struct {
+	waiters map[int][]chan quoteResult
+	timer   *time.Timer
+}
+
+// NewQuoter creates a Quoter sending its batches through the client.
+func (c *CoinmarketcapClient) NewQuoter(config QuoterConfig) *Quoter {
+	if config.Window <= 0 {
+		config.Window = 10 * time.Millisecond
+	}
+	if config.MaxIds <= 0 {
+		config.MaxIds = 100
+	}
+
+	return &Quoter{client: c, config: config}
+}
+
+// Quote returns the latest quote of the cryptocurrency with the given id.
+// The lookup is sent together with all other lookups made within the
+// batching window. An id without quote yields an error matching ErrInvalidId.
+func (q *Quoter) Quote(ctx context.Context, id int) (types.CryptocurrencyQuote, error) {
+	// an invalid id would fail the validation of the whole batch
+	if id < 1 {
+		return types.CryptocurrencyQuote{}, fmt.Errorf("%w: %d", ErrInvalidId, id)
+	}
+
+	result := make(chan quoteResult, 1)
+
+	q.mu.Lock()
+	if q.batch == nil {
+		batch := &quoteBatch{waiters: make(map[int][]chan quoteResult)}
+		batch.timer = time.AfterFunc(q.config.Window, func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			if q.batch == batch {
+				q.batch = nil
+				go q.send(batch)
+			}
+		})
+		q.batch = batch
+	}
+
+	batch := q.batch
+	batch.waiters[id] = append(batch.waiters[id], result)
+	if len(batch.waiters) >= q.config.MaxIds {
+		batch.timer.Stop()
+		q.batch = nil
+		go q.send(batch)
+	}
+	q.mu.Unlock()
+
+	select {
+	case r := <-result:
+		return r.quote, r.err
+	case <-ctx.Done():
+		return types.CryptocurrencyQuote{}, &CanceledError{Err: ctx.Err()}
+	}
+}
+
+// send performs the call for a batch and hands every waiter its result.
+// The call is not bound to any caller's context since it serves all of them.
+func (q *Quoter) send(batch *quoteBatch) {
+	ids := make([]int, 0, len(batch.waiters))
+	for id := range batch.waiters {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	request := q.config.Request
+	request.Id, request.Ids = "", ids
+	request.Slug, request.Slugs = "", nil
+	request.Symbol, request.Symbols = "", nil
+	request.SkipInvalid = true
+
+	// batches larger than the chunk size may fail partially, in which case
+	// only the ids of the failed chunks get the error
+	quotes, err := q.client.CryptocurrencyQuotesLatestWithContext(context.Background(), &request)
+	for _, id := range ids {
+		var result quoteResult
+		if quote, ok := quotes[strconv.Itoa(id)]; ok {
+			result.quote = quote
+		} else if err != nil {
+			result.err = err
+		} else {
+			result.err = fmt.Errorf("%w: %d", ErrInvalidId, id)
+		}
+
+		for _, waiter := range batch.waiters[id] {
+			waiter <- result
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func quotesHandler(t *testing.T, calls *int32, ids *[]string) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		assert.Equal(t, "true", r.URL.Query().Get("skip_invalid"))
+		assert.Equal(t, "EUR", r.URL.Query().Get("convert"))
+
+		mu.Lock()
+		*ids = append(*ids, r.URL.Query().Get("id"))
+		mu.Unlock()
+
+		var quotes []string
+		for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+			if id != "999999" {
+				quotes = append(quotes, `"`+id+`":{"id":`+id+`}`)
+			}
+		}
+		w.Write([]byte(`{"status":{},"data":{` + strings.Join(quotes, ",") + `}}`))
+	}
+}
+
+// waitForLookups waits until the open batch of quoter holds n lookups.
+func waitForLookups(t *testing.T, quoter *Quoter, n int) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		quoter.mu.Lock()
+		queued := 0
+		if quoter.batch != nil {
+			for _, waiters := range quoter.batch.waiters {
+				queued += len(waiters)
+			}
+		}
+		quoter.mu.Unlock()
+
+		if queued == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d lookups queued, expected %d", queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestQuoter_BatchesConcurrentLookups(t *testing.T) {
+	var calls int32
+	var ids []string
+	c, _ := newTestServer(t, quotesHandler(t, &calls, &ids))
+
+	// the batch is sent once it holds all four distinct ids
+	quoter := c.NewQuoter(QuoterConfig{
+		Window:  time.Hour,
+		MaxIds:  4,
+		Request: types.CryptocurrencyQuotesLatestRequest{Convert: "EUR"},
+	})
+
+	lookups := []int{1, 1027, 1, 999999, 825}
+	errs := make([]error, len(lookups))
+	quotes := make([]types.CryptocurrencyQuote, len(lookups))
+
+	var wg sync.WaitGroup
+	for i, id := range lookups {
+		// the last distinct id completes the batch, so it must come after
+		// the duplicate lookup
+		if i == len(lookups)-1 {
+			waitForLookups(t, quoter, len(lookups)-1)
+		}
+
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			quotes[i], errs[i] = quoter.Quote(context.Background(), id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []string{"1,825,1027,999999"}, ids)
+	for i, id := range lookups {
+		if id == 999999 {
+			assert.True(t, errors.Is(errs[i], ErrInvalidId), "unexpected error: %v", errs[i])
+			continue
+		}
+		assert.NoError(t, errs[i])
+		assert.Equal(t, id, quotes[i].Id)
+	}
+}
+
+func TestQuoter_SplitsFullBatches(t *testing.T) {
+	var calls int32
+	var ids []string
+	c, _ := newTestServer(t, quotesHandler(t, &calls, &ids))
+
+	quoter := c.NewQuoter(QuoterConfig{
+		Window:  time.Hour,
+		MaxIds:  2,
+		Request: types.CryptocurrencyQuotesLatestRequest{Convert: "EUR"},
+	})
+
+	var wg sync.WaitGroup
+	for _, id := range []int{1, 2, 3, 4} {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			quote, err := quoter.Quote(context.Background(), id)
+			assert.NoError(t, err)
+			assert.Equal(t, id, quote.Id)
+		}(id)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestQuoter_CallerCancellation(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":{},"data":{}}`))
+	})
+	quoter := c.NewQuoter(QuoterConfig{Window: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := quoter.Quote(ctx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestQuoter_RejectsInvalidIds(t *testing.T) {
+	var calls int32
+	var ids []string
+	c, _ := newTestServer(t, quotesHandler(t, &calls, &ids))
+	quoter := c.NewQuoter(QuoterConfig{
+		Window:  time.Hour,
+		MaxIds:  1,
+		Request: types.CryptocurrencyQuotesLatestRequest{Convert: "EUR"},
+	})
+
+	var wg sync.WaitGroup
+	for _, id := range []int{0, -1} {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			_, err := quoter.Quote(context.Background(), id)
+			assert.True(t, errors.Is(err, ErrInvalidId), "unexpected error: %v", err)
+		}(id)
+	}
+
+	quote, err := quoter.Quote(context.Background(), 1)
+	wg.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, quote.Id)
+	assert.Equal(t, []string{"1"}, ids)
+}
+
+func TestQuoter_PartialChunkFailure(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		if strings.Contains(id, "3") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":{"error_code":400,"error_message":"Invalid value for \"id\""}}`))
+			return
+		}
+
+		var quotes []string
+		for _, id := range strings.Split(id, ",") {
+			quotes = append(quotes, `"`+id+`":{"id":`+id+`}`)
+		}
+		w.Write([]byte(`{"status":{},"data":{` + strings.Join(quotes, ",") + `}}`))
+	})
+	WithChunkSize(2)(c)
+	quoter := c.NewQuoter(QuoterConfig{Window: time.Hour, MaxIds: 4})
+
+	errs := make(map[int]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, id := range []int{1, 2, 3, 4} {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			_, err := quoter.Quote(context.Background(), id)
+			mu.Lock()
+			errs[id] = err
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	assert.NoError(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.True(t, errors.Is(errs[3], ErrInvalidParameter), "unexpected error: %v", errs[3])
+	assert.True(t, errors.Is(errs[4], ErrInvalidParameter), "unexpected error: %v", errs[4])
+}
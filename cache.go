@@ -0,0 +1,179 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CacheEntry is a successful API response stored in a Cache.
+type CacheEntry struct {
+	//The raw response body, i.e. the JSON envelope with data and status.
+	Body []byte
+
+	//When the response was received.
+	StoredAt time.Time
+
+	//When the response stops being fresh.
+	Expires time.Time
+}
+
+// Cache stores API responses. Implementations must be safe for concurrent
+// use. Expired entries may be kept, the client checks freshness itself.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// CacheMode controls how a single call uses the client's cache.
+type CacheMode int
+
+const (
+	//Serve fresh entries from the cache and store new responses.
+	CacheDefault CacheMode = iota
+
+	//Always call the API and store the response, replacing any cached entry.
+	CacheRefresh
+
+	//Neither read from nor write to the cache.
+	CacheBypass
+)
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context that makes calls made with it use the cache
+// according to mode.
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+func cacheModeFrom(ctx context.Context) CacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(CacheMode)
+	return mode
+}
+
+// WithCache enables caching of responses in cache. Each endpoint is cached
+// for its default TTL, which WithCacheTTL overrides.
+func WithCache(cache Cache) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.cache = cache
+		return nil
+	}
+}
+
+// WithCacheTTL overrides the TTL of an endpoint, identified by the name of
+// its client method, e.g. "CryptocurrencyQuotesLatest". Zero disables caching
+// of the endpoint.
+func WithCacheTTL(endpoint string, ttl time.Duration) Option {
+	return func(c *CoinmarketcapClient) error {
+		if c.cacheTTLs == nil {
+			c.cacheTTLs = make(map[string]time.Duration)
+		}
+		c.cacheTTLs[endpoint] = ttl
+		return nil
+	}
+}
+
+func (c *CoinmarketcapClient) cacheTTL(endpoint string, defaultTTL time.Duration) time.Duration {
+	if ttl, ok := c.cacheTTLs[endpoint]; ok {
+		return ttl
+	}
+	return defaultTTL
+}
+
+// cacheKey identifies a call in the cache. It includes the base URL since a
+// cache may be shared by clients of different environments.
+func (c *CoinmarketcapClient) cacheKey(endpoint string, rawQuery string) string {
+	return c.url("") + " " + endpoint + "?" + rawQuery
+}
+
+// CacheResult reports how a call was served from the cache.
+type CacheResult struct {
+	//Whether the result came from the cache instead of the API.
+	Hit bool
+
+	//Whether the cached result had already expired.
+	Stale bool
+
+	//Time since the cached response was received from the API.
+	Age time.Duration
+
+	//The upstream error a stale result was served in place of, if any.
+	Err error
+}
+
+type cacheResultKey struct{}
+
+// WithCacheResult returns a context that makes calls made with it fill in
+// result. result is left untouched if the call was not served from the cache.
+func WithCacheResult(ctx context.Context, result *CacheResult) context.Context {
+	return context.WithValue(ctx, cacheResultKey{}, result)
+}
+
+func reportCacheResult(ctx context.Context, entry *CacheEntry, stale bool, err error) {
+	if result, ok := ctx.Value(cacheResultKey{}).(*CacheResult); ok {
+		*result = CacheResult{Hit: true, Stale: stale, Age: time.Since(entry.StoredAt), Err: err}
+	}
+}
+
+// WithStaleWhileRevalidate serves entries that expired less than maxStale ago
+// immediately and refreshes them in the background.
+func WithStaleWhileRevalidate(maxStale time.Duration) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.staleWhileRevalidate = maxStale
+		return nil
+	}
+}
+
+// WithStaleIfError serves entries that expired less than maxStale ago when
+// the API is unavailable or slow: on transport errors including timeouts of
+// the http.Client, 5xx responses and rate limits.
+func WithStaleIfError(maxStale time.Duration) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.staleIfError = maxStale
+		return nil
+	}
+}
+
+// serveStaleOnError reports whether err indicates that the API is unavailable
+// rather than that the request itself is wrong.
+func serveStaleOnError(err error) bool {
+	if errors.Is(err, ErrCanceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || errors.Is(apiErr, ErrRateLimited)
+	}
+	return true
+}
+
+// revalidate runs refresh in the background unless a refresh of key is
+// already running. The refresh is detached from the caller's context.
+func (c *CoinmarketcapClient) revalidate(key string, refresh func(ctx context.Context) error) {
+	c.revalidationsMu.Lock()
+	if c.revalidations == nil {
+		c.revalidations = make(map[string]bool)
+	}
+	if c.revalidations[key] {
+		c.revalidationsMu.Unlock()
+		return
+	}
+	c.revalidations[key] = true
+	c.revalidationsMu.Unlock()
+
+	go func() {
+		defer func() {
+			c.revalidationsMu.Lock()
+			delete(c.revalidations, key)
+			c.revalidationsMu.Unlock()
+		}()
+
+		if err := refresh(context.Background()); err != nil {
+			c.logger.WithField("key", key).Warnf("coinmarketcap: revalidating cached response: %s", err)
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package coinmarketcap_go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache is a Cache storing every entry as a JSON file in a directory,
+// so cached responses survive restarts. Entries are only removed by Delete
+// or when they are overwritten.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a DiskCache in dir, creating the directory if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	content, err := ioutil.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (d *DiskCache) Set(key string, entry *CacheEntry) {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// write to a temporary file first so readers never see a partial entry
+	tmp, err := ioutil.TempFile(d.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (d *DiskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
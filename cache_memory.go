@@ -0,0 +1,82 @@
+package coinmarketcap_go
+
+import (
+	"container/list"
+	"sync"
+)
+
+// MemoryCache is an in-memory Cache evicting the least recently used entry
+// once it holds more than its capacity.
+type MemoryCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
@@ -0,0 +1,306 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", &CacheEntry{Body: []byte("a")})
+	cache.Set("b", &CacheEntry{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{Body: []byte("c")})
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "least recently used entry was not evicted")
+	entry, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), entry.Body)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+	cache.Set("FiatMap?limit=10", &CacheEntry{Body: []byte(`{"data":[]}`), StoredAt: stored, Expires: stored.Add(time.Hour)})
+
+	reopened, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := reopened.Get("FiatMap?limit=10")
+	if assert.True(t, ok) {
+		assert.Equal(t, []byte(`{"data":[]}`), entry.Body)
+		assert.True(t, stored.Equal(entry.StoredAt))
+		assert.True(t, stored.Add(time.Hour).Equal(entry.Expires))
+	}
+
+	reopened.Delete("FiatMap?limit=10")
+	_, ok = cache.Get("FiatMap?limit=10")
+	assert.False(t, ok)
+}
+
+func TestCoinmarketcapClient_Cache(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":{"credit_count":1},"data":{"BTC":{"id":1,"symbol":"BTC"}}}`))
+	})
+	WithCache(NewMemoryCache(10))(c)
+
+	request := &types.CryptocurrencyQuotesLatestRequest{Symbol: "BTC"}
+	for i := 0; i < 3; i++ {
+		quotes, err := c.CryptocurrencyQuotesLatest(request)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, quotes["BTC"].Id)
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, c.CreditUsage().Total, "cache hits must not count credits")
+
+	// a different query is a different entry
+	_, err := c.CryptocurrencyQuotesLatest(&types.CryptocurrencyQuotesLatestRequest{Symbol: "BTC", Convert: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = c.CryptocurrencyQuotesLatestWithContext(WithCacheMode(context.Background(), CacheRefresh), request)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	_, err = c.CryptocurrencyQuotesLatestWithContext(WithCacheMode(context.Background(), CacheBypass), request)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+
+	_, err = c.CryptocurrencyQuotesLatest(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestCoinmarketcapClient_CacheTTL(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":{},"data":[]}`))
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+	WithCacheTTL("FiatMap", 0)(c)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.FiatMap(&types.FiatMapRequest{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 0, cache.Len())
+
+	// expired entries are not served
+	WithCacheTTL("ExchangeIdMap", time.Hour)(c)
+	_, err := c.ExchangeIdMap(&types.ExchangeIdMapRequest{})
+	assert.NoError(t, err)
+	entry, _ := cache.Get(c.cacheKey("ExchangeIdMap", ""))
+	entry.Expires = time.Now().Add(-time.Second)
+
+	_, err = c.ExchangeIdMap(&types.ExchangeIdMapRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestCoinmarketcapClient_CacheSkipsErrors(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func expireCacheEntry(t *testing.T, cache *MemoryCache, key string, ago time.Duration) {
+	entry, ok := cache.Get(key)
+	if !ok {
+		t.Fatalf("no cache entry for %s", key)
+	}
+	cache.Set(key, &CacheEntry{Body: entry.Body, StoredAt: entry.StoredAt.Add(-ago), Expires: time.Now().Add(-ago)})
+}
+
+func TestCoinmarketcapClient_StaleWhileRevalidate(t *testing.T) {
+	calls := make(chan struct{}, 10)
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls <- struct{}{}
+		w.Write([]byte(`{"status":{},"data":{"btc_dominance":` + []string{"60", "61", "62"}[len(calls)-1] + `}}`))
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+	WithStaleWhileRevalidate(5 * time.Minute)(c)
+
+	metrics, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance)
+
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 2*time.Minute)
+
+	var result CacheResult
+	metrics, err = c.GlobalMetricsQuotesLatestWithContext(WithCacheResult(context.Background(), &result), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance, "stale entry was not served immediately")
+	assert.True(t, result.Hit)
+	assert.True(t, result.Stale)
+	assert.True(t, result.Age >= 2*time.Minute)
+
+	// wait for the background refresh to replace the entry
+	deadline := time.Now().Add(time.Second)
+	for {
+		entry, _ := cache.Get(c.cacheKey("GlobalMetricsQuotesLatest", ""))
+		if time.Now().Before(entry.Expires) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cache entry was not revalidated")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Len(t, calls, 2)
+
+	result = CacheResult{}
+	metrics, err = c.GlobalMetricsQuotesLatestWithContext(WithCacheResult(context.Background(), &result), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 61.0, metrics.BTCDominance)
+	assert.True(t, result.Hit)
+	assert.False(t, result.Stale)
+}
+
+func TestCoinmarketcapClient_StaleIfError(t *testing.T) {
+	failing := false
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":{},"data":{"btc_dominance":60}}`))
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+	WithStaleIfError(10 * time.Minute)(c)
+
+	_, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+
+	failing = true
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 2*time.Minute)
+
+	var result CacheResult
+	metrics, err := c.GlobalMetricsQuotesLatestWithContext(WithCacheResult(context.Background(), &result), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance)
+	assert.True(t, result.Stale)
+	assert.Error(t, result.Err)
+
+	// too old to be served
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 20*time.Minute)
+	_, err = c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.Error(t, err)
+}
+
+func TestServeStaleOnError(t *testing.T) {
+	assert.True(t, serveStaleOnError(&APIError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, serveStaleOnError(&APIError{StatusCode: http.StatusTooManyRequests, ErrorCode: ErrorCodeMinuteRateLimitReached}))
+	assert.False(t, serveStaleOnError(&APIError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, serveStaleOnError(&CanceledError{Err: context.Canceled}))
+}
+
+func TestCoinmarketcapClient_CacheSharedByEnvironments(t *testing.T) {
+	cache := NewMemoryCache(10)
+	handler := func(dominance string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"status":{},"data":{"btc_dominance":` + dominance + `}}`))
+		}
+	}
+	production, _ := newTestServer(t, handler("60"))
+	sandbox, _ := newTestServer(t, handler("1"))
+	WithCache(cache)(production)
+	WithCache(cache)(sandbox)
+
+	metrics, err := sandbox.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, metrics.BTCDominance)
+
+	metrics, err = production.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance, "served the cached response of another environment")
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestCoinmarketcapClient_CacheWithoutCredentials(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":{},"data":{"btc_dominance":60}}`))
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+	WithStaleIfError(10 * time.Minute)(c)
+
+	_, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+
+	// the key file disappears after the response was cached
+	WithCredentials(NewFileApiKey("testdata/missing-api-key"))(c)
+
+	metrics, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance)
+
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 2*time.Minute)
+	var result CacheResult
+	metrics, err = c.GlobalMetricsQuotesLatestWithContext(WithCacheResult(context.Background(), &result), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance)
+	assert.True(t, result.Stale)
+	assert.Error(t, result.Err)
+}
+
+func TestCoinmarketcapClient_StaleIfErrorOnClientTimeout(t *testing.T) {
+	var slow int32
+	cache := NewMemoryCache(10)
+	c, calls := newTimeoutTestServer(t, 20*time.Millisecond, func() bool { return atomic.LoadInt32(&slow) == 1 },
+		`{"status":{},"data":{"btc_dominance":60}}`, WithCache(cache), WithStaleIfError(10*time.Minute))
+
+	_, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+
+	atomic.StoreInt32(&slow, 1)
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 2*time.Minute)
+
+	var result CacheResult
+	metrics, err := c.GlobalMetricsQuotesLatestWithContext(WithCacheResult(context.Background(), &result), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, metrics.BTCDominance)
+	assert.True(t, result.Stale)
+	assert.Error(t, result.Err)
+	assert.False(t, errors.Is(result.Err, ErrCanceled))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
@@ -0,0 +1,194 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//Default maximum number of ids, slugs or symbols sent in one call.
+	DefaultChunkSize = 100
+
+	//Maximum length of a single comma-separated list, keeping URLs well below common limits.
+	maxChunkLength = 2000
+)
+
+// ChunkFailure is a chunk of a split call that failed.
+type ChunkFailure struct {
+	//The comma-separated ids, slugs or symbols of the chunk.
+	List string
+	Err  error
+}
+
+// ChunkError is returned when some chunks of a call that was split into
+// several requests failed. The merged results of the successful chunks are
+// returned alongside it.
+type ChunkError struct {
+	Endpoint string
+	Failures []ChunkFailure
+	Chunks   int
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("%s: %d of %d chunks failed, first error: %s", e.Endpoint, len(e.Failures), e.Chunks, e.Failures[0].Err)
+}
+
+// Is reports whether the error of any failed chunk matches target. It is
+// implemented explicitly since errors.Is only follows multiple wrapped errors
+// from Go 1.20 on.
+func (e *ChunkError) Is(target error) bool {
+	for _, failure := range e.Failures {
+		if errors.Is(failure.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of a failed chunk that matches target.
+func (e *ChunkError) As(target interface{}) bool {
+	for _, failure := range e.Failures {
+		if errors.As(failure.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithChunkSize sets how many ids, slugs or symbols are sent per call by the
+// multi-asset endpoints. Longer lists are split into several calls.
+func WithChunkSize(size int) Option {
+	return func(c *CoinmarketcapClient) error {
+		if size < 1 {
+			return fmt.Errorf("chunk size must be positive, got %d", size)
+		}
+		c.chunkSize = size
+		return nil
+	}
+}
+
+// splitList splits a comma-separated list into chunks of at most size items
+// and maxLength characters.
+func splitList(list string, size int, maxLength int) []string {
+	var chunks []string
+	var current []string
+	length := 0
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if len(current) == size || len(current) > 0 && length+1+len(item) > maxLength {
+			chunks = append(chunks, strings.Join(current, ","))
+			current, length = nil, 0
+		}
+		if len(current) > 0 {
+			length++
+		}
+		current = append(current, item)
+		length += len(item)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, ","))
+	}
+
+	return chunks
+}
+
+// executeChunked is execute for endpoints returning data keyed by asset. If
+// the request lists more assets than fit in one call, it is split into
+// chunks which are executed concurrently, sharing the client's limiter, and
+// their results are merged.
+func executeChunked[Req any, V any](ctx context.Context, c *CoinmarketcapClient, ep endpoint[Req, map[string]V], request *Req) (*Response[map[string]V], error) {
+	start := time.Now()
+	size := c.chunkSize
+	if size == 0 {
+		size = DefaultChunkSize
+	}
+
+	values, err := encodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ep.name, err)
+	}
+
+	// only split if exactly one list is given, the API rejects combinations anyway
+	key := ""
+	for _, list := range ep.lists {
+		if values.Get(list) != "" {
+			if key != "" {
+				return executeQuery(ctx, c, ep, request, values)
+			}
+			key = list
+		}
+	}
+	if key == "" {
+		return executeQuery(ctx, c, ep, request, values)
+	}
+
+	chunks := splitList(values.Get(key), size, maxChunkLength)
+	if len(chunks) <= 1 {
+		return executeQuery(ctx, c, ep, request, values)
+	}
+
+	responses := make([]*Response[map[string]V], len(chunks))
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		chunkValues := make(url.Values, len(values))
+		for k, v := range values {
+			chunkValues[k] = v
+		}
+		chunkValues.Set(key, chunk)
+
+		wg.Add(1)
+		go func(i int, chunkValues url.Values) {
+			defer wg.Done()
+			responses[i], errs[i] = executeQuery(ctx, c, ep, request, chunkValues)
+		}(i, chunkValues)
+	}
+	wg.Wait()
+
+	// the merged response came from the cache only if all chunks did, its
+	// HTTP status and headers are those of the first successful chunk
+	merged := &Response[map[string]V]{Data: make(map[string]V), FromCache: true}
+	chunkErr := &ChunkError{Endpoint: ep.name, Chunks: len(chunks)}
+	for i, response := range responses {
+		if errs[i] != nil {
+			chunkErr.Failures = append(chunkErr.Failures, ChunkFailure{List: chunks[i], Err: errs[i]})
+			continue
+		}
+
+		for key, value := range response.Data {
+			merged.Data[key] = value
+		}
+		merged.Status.CreditCount += response.Status.CreditCount
+		merged.Status.Elapsed += response.Status.Elapsed
+		if merged.Status.Timestamp == nil || response.Status.Timestamp != nil && response.Status.Timestamp.After(*merged.Status.Timestamp) {
+			merged.Status.Timestamp = response.Status.Timestamp
+		}
+		if merged.HTTPStatus == 0 {
+			merged.HTTPStatus, merged.Header = response.HTTPStatus, response.Header
+		}
+		merged.WireBytes += response.WireBytes
+		merged.DecodedBytes += response.DecodedBytes
+		merged.FromCache = merged.FromCache && response.FromCache
+		merged.Stale = merged.Stale || response.Stale
+		if response.Age > merged.Age {
+			merged.Age = response.Age
+		}
+	}
+	merged.Latency = time.Since(start)
+
+	if len(chunkErr.Failures) == len(chunks) {
+		return nil, chunkErr
+	}
+	if len(chunkErr.Failures) > 0 {
+		return merged, chunkErr
+	}
+	return merged, nil
+}
@@ -0,0 +1,110 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"1,2", "3,4", "5"}, splitList("1,2,3,4,5", 2, 100))
+	assert.Equal(t, []string{"1,2,3"}, splitList("1, 2,,3", 10, 100))
+	assert.Equal(t, []string{"aaaa,bb", "cccc"}, splitList("aaaa,bb,cccc", 10, 8))
+	assert.Nil(t, splitList("", 10, 100))
+}
+
+func TestCoinmarketcapClient_ChunksLongIdLists(t *testing.T) {
+	var mu sync.Mutex
+	var lists []string
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		list := r.URL.Query().Get("id")
+		mu.Lock()
+		lists = append(lists, list)
+		mu.Unlock()
+
+		var quotes []string
+		for _, id := range strings.Split(list, ",") {
+			quotes = append(quotes, fmt.Sprintf(`"%s":{"id":%s}`, id, id))
+		}
+		w.Write([]byte(`{"status":{"credit_count":1},"data":{` + strings.Join(quotes, ",") + `}}`))
+	})
+	WithChunkSize(3)(c)
+
+	quotes, err := c.CryptocurrencyQuotesLatest(&types.CryptocurrencyQuotesLatestRequest{Id: "1,2,3,4,5,6,7"})
+	assert.NoError(t, err)
+	assert.Len(t, quotes, 7)
+	assert.Equal(t, 7, quotes["7"].Id)
+	assert.ElementsMatch(t, []string{"1,2,3", "4,5,6", "7"}, lists)
+	assert.Equal(t, 3, c.CreditUsage().Total)
+}
+
+func TestCoinmarketcapClient_ChunkPartialFailure(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		list := r.URL.Query().Get("slug")
+		if strings.Contains(list, "broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":{"error_code":400,"error_message":"Invalid value for \"slug\": \"broken\""}}`))
+			return
+		}
+
+		var infos []string
+		for _, slug := range strings.Split(list, ",") {
+			infos = append(infos, fmt.Sprintf(`"%s":{"slug":"%s"}`, slug, slug))
+		}
+		w.Write([]byte(`{"status":{},"data":{` + strings.Join(infos, ",") + `}}`))
+	})
+	WithChunkSize(2)(c)
+
+	infos, err := c.ExchangeInfo(&types.ExchangeInfoRequest{Slug: "binance,kraken,broken,bitstamp"})
+
+	var chunkErr *ChunkError
+	if assert.True(t, errors.As(err, &chunkErr)) {
+		assert.Equal(t, 2, chunkErr.Chunks)
+		if assert.Len(t, chunkErr.Failures, 1) {
+			assert.Equal(t, "broken,bitstamp", chunkErr.Failures[0].List)
+		}
+	}
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	}
+	assert.Len(t, infos, 2)
+	assert.NotNil(t, infos["kraken"])
+}
+
+func TestCoinmarketcapClient_NoChunkingForShortLists(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "BTC,ETH", r.URL.Query().Get("symbol"))
+		w.Write([]byte(`{"status":{},"data":{"BTC":{},"ETH":{}}}`))
+	})
+
+	ohlcv, err := c.CryptocurrencyOHLCVLatest(&types.CryptocurrencyOHLCVLatestRequest{Symbol: "BTC,ETH"})
+	assert.NoError(t, err)
+	assert.Len(t, ohlcv, 2)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCoinmarketcapClient_ChunksTypedIdLists(t *testing.T) {
+	var mu sync.Mutex
+	var lists []string
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lists = append(lists, r.URL.Query().Get("id"))
+		mu.Unlock()
+		w.Write([]byte(`{"status":{},"data":{}}`))
+	})
+	WithChunkSize(2)(c)
+
+	_, err := c.CryptocurrencyInfo(&types.CryptocurrencyInfoRequest{Id: "1", Ids: []int{2, 3}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1,2", "3"}, lists)
+}
@@ -0,0 +1,67 @@
+package coinmarketcap_go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// newTestServer starts an httptest server and returns a client pointed at it
+// with a limiter that does not slow the tests down.
+func newTestServer(t testing.TB, handler http.HandlerFunc) (*CoinmarketcapClient, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(
+		WithLimiter(rate.NewLimiter(rate.Inf, 1)),
+		WithApiKey("test-key"),
+		WithBaseURL(server.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, server
+}
+
+func TestCoinmarketcapClient_DefaultEnvironment(t *testing.T) {
+	c := &CoinmarketcapClient{}
+	if err := c.Init(types.Basic); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, API_URL+"/v1/fiat/map", c.url("/v1/fiat/map"))
+
+	c.SetEnvironment(Sandbox)
+	assert.Equal(t, SANDBOX_URL+"/v1/fiat/map", c.url("/v1/fiat/map"))
+}
+
+func TestCoinmarketcapClient_SetBaseURL(t *testing.T) {
+	c := &CoinmarketcapClient{}
+
+	assert.Error(t, c.SetBaseURL("localhost:8080"))
+	assert.Error(t, c.SetBaseURL("ftp://localhost"))
+
+	assert.NoError(t, c.SetBaseURL("http://localhost:8080/cmc/"))
+	assert.Equal(t, "http://localhost:8080/cmc/v1/fiat/map", c.url("/v1/fiat/map"))
+}
+
+func TestCoinmarketcapClient_CustomBaseURL(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/fiat/map", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"status":{"error_code":0},"data":[{"id":2781,"name":"United States Dollar","sign":"$","symbol":"USD"}]}`))
+	})
+
+	fiats, err := c.FiatMap(&types.FiatMapRequest{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, fiats, 1)
+	assert.Equal(t, "USD", fiats[0].Symbol)
+}
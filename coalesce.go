@@ -0,0 +1,104 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRequestCoalescing makes identical concurrent calls, i.e. calls to the
+// same endpoint with the same encoded query, share a single upstream request.
+// All callers receive the same decoded result, which must therefore be
+// treated as read-only.
+func WithRequestCoalescing() Option {
+	return func(c *CoinmarketcapClient) error {
+		c.flights = &flightGroup{}
+		return nil
+	}
+}
+
+// flight is an upstream call shared by one or more callers.
+type flight struct {
+	done    chan struct{}
+	value   interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup deduplicates concurrent calls by key. Unlike a plain
+// singleflight, a caller whose context is done stops waiting without
+// aborting the call; the call is only canceled once every caller gave up.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, ok := g.flights[key]
+	if ok {
+		f.waiters++
+	} else {
+		// the shared call keeps the values of the first caller's context,
+		// e.g. for tracing, but not its cancellation
+		callCtx, cancel := context.WithCancel(detachedContext{ctx})
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.flights[key] = f
+
+		go func() {
+			f.value, f.err = fn(callCtx)
+			cancel()
+
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// later callers must not join the canceled call
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, &CanceledError{Err: ctx.Err()}
+	}
+}
+
+// detachedContext carries the values of its parent but is never canceled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
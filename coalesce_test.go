@@ -0,0 +1,154 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForWaiters waits until the flight of key has n waiters.
+func waitForWaiters(t *testing.T, group *flightGroup, key string, n int) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		group.mu.Lock()
+		waiters := 0
+		if f, ok := group.flights[key]; ok {
+			waiters = f.waiters
+		}
+		group.mu.Unlock()
+
+		if waiters == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("flight %s has %d waiters, expected %d", key, waiters, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoinmarketcapClient_RequestCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Write([]byte(`{"status":{"credit_count":1},"data":{"BTC":{"id":1,"symbol":"BTC"}}}`))
+	})
+	WithRequestCoalescing()(c)
+
+	const callers = 20
+	results := make([]map[string]types.CryptocurrencyQuote, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			quotes, err := c.CryptocurrencyQuotesLatest(&types.CryptocurrencyQuotesLatestRequest{Symbol: "BTC"})
+			assert.NoError(t, err)
+			results[i] = quotes
+		}(i)
+	}
+
+	waitForWaiters(t, c.flights, c.cacheKey("CryptocurrencyQuotesLatest", "symbol=BTC"), callers)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, c.CreditUsage().Total)
+	for _, quotes := range results {
+		assert.Equal(t, 1, quotes["BTC"].Id)
+	}
+}
+
+func TestFlightGroup_CallerCancellation(t *testing.T) {
+	group := &flightGroup{}
+	release := make(chan struct{})
+	sharedCtx := make(chan context.Context, 1)
+	fn := func(ctx context.Context) (interface{}, error) {
+		sharedCtx <- ctx
+		<-release
+		return "result", nil
+	}
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := group.do(canceledCtx, "key", fn)
+		first <- err
+	}()
+
+	second := make(chan interface{}, 1)
+	waitForWaiters(t, group, "key", 1)
+	go func() {
+		value, err := group.do(context.Background(), "key", fn)
+		assert.NoError(t, err)
+		second <- value
+	}()
+
+	waitForWaiters(t, group, "key", 2)
+	cancel()
+	err := <-first
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	// the shared call keeps running for the remaining caller, it would have
+	// been canceled before the first caller returned
+	assert.NoError(t, (<-sharedCtx).Err())
+
+	close(release)
+	assert.Equal(t, "result", <-second)
+}
+
+func TestFlightGroup_LastCallerCancelsSharedCall(t *testing.T) {
+	group := &flightGroup{}
+	aborted := make(chan struct{})
+
+	// the only caller gives up right away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := group.do(ctx, "key", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		close(aborted)
+		return nil, ctx.Err()
+	})
+	assert.True(t, errors.Is(err, ErrCanceled))
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("shared call was not canceled after the last caller gave up")
+	}
+}
+
+func TestFlightGroup_CallerAfterCancellationStartsNewCall(t *testing.T) {
+	group := &flightGroup{}
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the canceled call only returns once released, so it is still in
+	// flight when the next caller arrives
+	_, err := group.do(ctx, "key", func(ctx context.Context) (interface{}, error) {
+		<-release
+		return nil, ctx.Err()
+	})
+	assert.True(t, errors.Is(err, ErrCanceled))
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, err := group.do(ctx, "key", func(ctx context.Context) (interface{}, error) {
+		return "result", ctx.Err()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "result", value)
+}
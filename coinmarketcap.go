@@ -2,16 +2,14 @@ package coinmarketcap_go
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/drankou/coinmarketcap-go/types"
-	"github.com/google/go-querystring/query"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/time/rate"
-	"io/ioutil"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -19,303 +17,220 @@ const (
 	SANDBOX_URL = "https://sandbox-api.coinmarketcap.com"
 )
 
+// DefaultUserAgent is sent as User-Agent unless one is set with WithUserAgent.
+const DefaultUserAgent = "coinmarketcap-go"
+
+// Environment is the base URL of a CoinMarketCap API deployment.
+type Environment string
+
+const (
+	Production Environment = API_URL
+	Sandbox    Environment = SANDBOX_URL
+)
+
 type CoinmarketcapClient struct {
-	client  *http.Client
-	limiter *rate.Limiter
+	client      *http.Client
+	limiter     RateLimiter
+	baseUrl     string
+	credentials CredentialProvider
+	userAgent   string
+	logger      log.FieldLogger
+	retryPolicy RetryPolicy
+	middleware  []Middleware
+	cache       Cache
+	cacheTTLs   map[string]time.Duration
+	credits     creditTracker
+
+	staleWhileRevalidate time.Duration
+	staleIfError         time.Duration
+	revalidationsMu      sync.Mutex
+	revalidations        map[string]bool
+	flights              *flightGroup
+	chunkSize            int
+	httpClientOptions    []func(*http.Client)
 }
 
+// Init prepares a zero CoinmarketcapClient for the given plan.
+// It is kept for compatibility, NewClient(WithPlan(plan)) is equivalent.
 func (c *CoinmarketcapClient) Init(plan types.ApiPlan) error {
 	c.client = &http.Client{}
-	c.limiter = rate.NewLimiter(types.APIRateLimits[plan], 1)
+	return c.init(WithPlan(plan))
+}
+
+// SetEnvironment points every endpoint of the client at the given environment.
+func (c *CoinmarketcapClient) SetEnvironment(env Environment) {
+	c.baseUrl = strings.TrimRight(string(env), "/")
+}
+
+// SetBaseURL points every endpoint of the client at a custom host, e.g. a local
+// stand-in server or a caching proxy. The URL may contain a path prefix.
+func (c *CoinmarketcapClient) SetBaseURL(baseUrl string) error {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid base url %q: scheme and host are required", baseUrl)
+	}
+
+	c.SetEnvironment(Environment(baseUrl))
 	return nil
 }
 
+// SetCredentials sets the provider consulted for the API key on every request.
+// By default the key is read from the CMC_PRO_API_KEY environment variable.
+func (c *CoinmarketcapClient) SetCredentials(credentials CredentialProvider) {
+	c.credentials = credentials
+}
+
+func (c *CoinmarketcapClient) apiKey(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		return EnvApiKey(DefaultApiKeyEnv).ApiKey(ctx)
+	}
+	return c.credentials.ApiKey(ctx)
+}
+
+func (c *CoinmarketcapClient) recordCredits(endpoint string, credits int) {
+	c.credits.record(endpoint, credits)
+}
+
+func (c *CoinmarketcapClient) url(path string) string {
+	if c.baseUrl == "" {
+		return API_URL + path
+	}
+	return c.baseUrl + path
+}
+
 // ------ Cryptocurrency ------ //
 
 // Returns a mapping of all cryptocurrencies to unique CoinMarketCap ids.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyMap
 func (c *CoinmarketcapClient) CryptocurrencyIdMap(request *types.CryptocurrencyMapRequest) ([]types.Cryptocurrency, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/map", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyIdMapWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// CryptocurrencyIdMapWithContext is the same as CryptocurrencyIdMap with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyIdMapWithContext(ctx context.Context, request *types.CryptocurrencyMapRequest) ([]types.Cryptocurrency, error) {
+	resp, err := c.CryptocurrencyIdMapWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyMapResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyIdMap: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // Returns all static metadata available for one or more cryptocurrencies.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyInfo
+// Lists of more than DefaultChunkSize (see WithChunkSize) assets are split into several calls;
+// if only some of them fail, the merged results of the others are returned with a *ChunkError.
 func (c *CoinmarketcapClient) CryptocurrencyInfo(request *types.CryptocurrencyInfoRequest) (map[string]*types.CryptocurrencyInfo, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/info", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyInfoWithContext(context.Background(), request)
+}
 
-	err = c.limiter.Wait(context.Background())
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.Do(httpRequest)
-	if err != nil {
+// CryptocurrencyInfoWithContext is the same as CryptocurrencyInfo with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyInfoWithContext(ctx context.Context, request *types.CryptocurrencyInfoRequest) (map[string]*types.CryptocurrencyInfo, error) {
+	resp, err := c.CryptocurrencyInfoWithResponse(ctx, request)
+	if resp == nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyInfoResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyInfo: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, err
 }
 
 // Returns a ranked and sorted list of all cryptocurrencies for a historical UTC date.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyListingsHistorical
 func (c *CoinmarketcapClient) CryptocurrencyListingsHistorical(request *types.CryptocurrencyListingsHistoricalRequest) ([]types.CryptocurrencyListing, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/listings/historical", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyListingsHistoricalWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// CryptocurrencyListingsHistoricalWithContext is the same as CryptocurrencyListingsHistorical with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyListingsHistoricalWithContext(ctx context.Context, request *types.CryptocurrencyListingsHistoricalRequest) ([]types.CryptocurrencyListing, error) {
+	resp, err := c.CryptocurrencyListingsHistoricalWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyListingsHistoricalResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyListingsHistorical: %d: %s:", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // Returns a paginated list of all active cryptocurrencies with latest market data.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyListingsLatest
 func (c *CoinmarketcapClient) CryptocurrencyListingsLatest(request *types.CryptocurrencyListingsLatestRequest) ([]types.CryptocurrencyListing, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/listings/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyListingsLatestWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// CryptocurrencyListingsLatestWithContext is the same as CryptocurrencyListingsLatest with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyListingsLatestWithContext(ctx context.Context, request *types.CryptocurrencyListingsLatestRequest) ([]types.CryptocurrencyListing, error) {
+	resp, err := c.CryptocurrencyListingsLatestWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyListingsLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyListingsLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 func (c *CoinmarketcapClient) CryptocurrencyOHLCVHistorical(request *types.CryptocurrencyOHLCVHistoricalRequest) (map[string]*types.OHLCVHistoricalResult, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/ohlcv/historical", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyOHLCVHistoricalWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// CryptocurrencyOHLCVHistoricalWithContext is the same as CryptocurrencyOHLCVHistorical with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVHistoricalWithContext(ctx context.Context, request *types.CryptocurrencyOHLCVHistoricalRequest) (map[string]*types.OHLCVHistoricalResult, error) {
+	resp, err := c.CryptocurrencyOHLCVHistoricalWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyOHLCVHistoricalResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyOHLCVHistorical: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // Returns the latest OHLCV (Open, High, Low, Close, Volume) market values for one or more cryptocurrencies for the current UTC day.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyOhlcvLatest
+// Lists of more than DefaultChunkSize (see WithChunkSize) assets are split into several calls;
+// if only some of them fail, the merged results of the others are returned with a *ChunkError.
 func (c *CoinmarketcapClient) CryptocurrencyOHLCVLatest(request *types.CryptocurrencyOHLCVLatestRequest) (map[string]*types.CryptocurrencyOHLCV, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/ohlcv/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyOHLCVLatestWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
+// CryptocurrencyOHLCVLatestWithContext is the same as CryptocurrencyOHLCVLatest with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVLatestWithContext(ctx context.Context, request *types.CryptocurrencyOHLCVLatestRequest) (map[string]*types.CryptocurrencyOHLCV, error) {
+	resp, err := c.CryptocurrencyOHLCVLatestWithResponse(ctx, request)
+	if resp == nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyOHLCVLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyOHLCVLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, err
 }
 
+// Returns the latest market quote for one or more cryptocurrencies.
+// https://pro.coinmarketcap.com/api/v1/#operation/getV1CryptocurrencyQuotesLatest
+// Lists of more than DefaultChunkSize (see WithChunkSize) assets are split into several calls;
+// if only some of them fail, the merged results of the others are returned with a *ChunkError.
 func (c *CoinmarketcapClient) CryptocurrencyQuotesLatest(request *types.CryptocurrencyQuotesLatestRequest) (map[string]types.CryptocurrencyQuote, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/quotes/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyQuotesLatestWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
+// CryptocurrencyQuotesLatestWithContext is the same as CryptocurrencyQuotesLatest with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyQuotesLatestWithContext(ctx context.Context, request *types.CryptocurrencyQuotesLatestRequest) (map[string]types.CryptocurrencyQuote, error) {
+	resp, err := c.CryptocurrencyQuotesLatestWithResponse(ctx, request)
+	if resp == nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyQuotesLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyQuotesLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, err
 }
 
 func (c *CoinmarketcapClient) CryptocurrencyPricePerformanceStats(request *types.CryptocurrencyPricePerformanceStatsRequest) (map[string]*types.PricePerformanceStats, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/cryptocurrency/price-performance-stats/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.CryptocurrencyPricePerformanceStatsWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// CryptocurrencyPricePerformanceStatsWithContext is the same as CryptocurrencyPricePerformanceStats with a custom context.
+func (c *CoinmarketcapClient) CryptocurrencyPricePerformanceStatsWithContext(ctx context.Context, request *types.CryptocurrencyPricePerformanceStatsRequest) (map[string]*types.PricePerformanceStats, error) {
+	resp, err := c.CryptocurrencyPricePerformanceStatsWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.CryptocurrencyPricePerformanceStatsResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("CryptocurrencyPricePerformanceStats: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // ------ Fiat ------ //
@@ -323,111 +238,54 @@ func (c *CoinmarketcapClient) CryptocurrencyPricePerformanceStats(request *types
 // Returns a mapping of all supported fiat currencies to unique CoinMarketCap ids.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1FiatMap
 func (c *CoinmarketcapClient) FiatMap(request *types.FiatMapRequest) ([]types.Fiat, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/fiat/map", nil)
-	if err != nil {
-		log.Error(err)
-	}
+	return c.FiatMapWithContext(context.Background(), request)
+}
 
-	err = prepareHttpRequest(httpRequest, request)
+// FiatMapWithContext is the same as FiatMap with a custom context.
+func (c *CoinmarketcapClient) FiatMapWithContext(ctx context.Context, request *types.FiatMapRequest) ([]types.Fiat, error) {
+	resp, err := c.FiatMapWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.FiatMapResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("FiatMap: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // ------ Exchange ------ //
 
 // Returns all static metadata for one or more exchanges.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1ExchangeInfo
+// Lists of more than DefaultChunkSize (see WithChunkSize) assets are split into several calls;
+// if only some of them fail, the merged results of the others are returned with a *ChunkError.
 func (c *CoinmarketcapClient) ExchangeInfo(request *types.ExchangeInfoRequest) (map[string]*types.ExchangeInfo, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/exchange/info", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.ExchangeInfoWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
+// ExchangeInfoWithContext is the same as ExchangeInfo with a custom context.
+func (c *CoinmarketcapClient) ExchangeInfoWithContext(ctx context.Context, request *types.ExchangeInfoRequest) (map[string]*types.ExchangeInfo, error) {
+	resp, err := c.ExchangeInfoWithResponse(ctx, request)
+	if resp == nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.ExchangeInfoResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("ExchangeInfo: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, err
 }
 
 // Returns a paginated list of all cryptocurrency exchanges by CoinMarketCap ID.
 // By default listing_status=active
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1ExchangeMap
 func (c *CoinmarketcapClient) ExchangeIdMap(request *types.ExchangeIdMapRequest) ([]types.Exchange, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/exchange/map", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.ExchangeIdMapWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// ExchangeIdMapWithContext is the same as ExchangeIdMap with a custom context.
+func (c *CoinmarketcapClient) ExchangeIdMapWithContext(ctx context.Context, request *types.ExchangeIdMapRequest) ([]types.Exchange, error) {
+	resp, err := c.ExchangeIdMapWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.ExchangeIdMapResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("ExchangeInfo: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
 // ------ Global-Metrics ------ //
@@ -435,165 +293,60 @@ func (c *CoinmarketcapClient) ExchangeIdMap(request *types.ExchangeIdMapRequest)
 // Returns the latest global cryptocurrency market metrics.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1GlobalmetricsQuotesLatest
 func (c *CoinmarketcapClient) GlobalMetricsQuotesLatest(request *types.GlobalMetricsQuotesLatestRequest) (*types.GlobalMetricsQuotesLatest, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/global-metrics/quotes/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
+	return c.GlobalMetricsQuotesLatestWithContext(context.Background(), request)
+}
 
-	err = prepareHttpRequest(httpRequest, request)
+// GlobalMetricsQuotesLatestWithContext is the same as GlobalMetricsQuotesLatest with a custom context.
+func (c *CoinmarketcapClient) GlobalMetricsQuotesLatestWithContext(ctx context.Context, request *types.GlobalMetricsQuotesLatestRequest) (*types.GlobalMetricsQuotesLatest, error) {
+	resp, err := c.GlobalMetricsQuotesLatestWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.GlobalMetricsQuotesLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return &cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("GlobalMetricsQuotesLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return &resp.Data, nil
 }
 
 // Returns an interval of historical global cryptocurrency market metrics based on time and interval parameters.
 // https://pro.coinmarketcap.com/api/v1/#operation/getV1GlobalmetricsQuotesHistorical
 func (c *CoinmarketcapClient) GlobalMetricsQuotesHistorical(request *types.GlobalMetricsQuotesHistoricalRequest) ([]types.AggregatedMarketQuote, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/global-metrics/quotes/historical", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
+	return c.GlobalMetricsQuotesHistoricalWithContext(context.Background(), request)
+}
 
-	resp, err := c.performHttpRequest(httpRequest)
+// GlobalMetricsQuotesHistoricalWithContext is the same as GlobalMetricsQuotesHistorical with a custom context.
+func (c *CoinmarketcapClient) GlobalMetricsQuotesHistoricalWithContext(ctx context.Context, request *types.GlobalMetricsQuotesHistoricalRequest) ([]types.AggregatedMarketQuote, error) {
+	resp, err := c.GlobalMetricsQuotesHistoricalWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.GlobalMetricsQuotesHistoricalResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data.Quotes, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("GlobalMetricsQuotesHistorical: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data.Quotes, nil
 }
 
 // ------ Partners ------ //
 func (c *CoinmarketcapClient) PartnersFCASListingsLatest(request *types.FCASListingsLatestRequest) ([]types.FCASRating, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/partners/flipside-crypto/fcas/listings/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.FCASListingsLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("PartnersFCASListingsLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return c.PartnersFCASListingsLatestWithContext(context.Background(), request)
 }
 
-func (c *CoinmarketcapClient) PartnersFCASQuotesLatest(request *types.FCASQuotesLatestRequest) (map[string]*types.FCASRating, error) {
-	httpRequest, err := http.NewRequest("GET", API_URL+"/v1/partners/flipside-crypto/fcas/quotes/latest", nil)
-	if err != nil {
-		log.Error(err)
-	}
-
-	err = prepareHttpRequest(httpRequest, request)
+// PartnersFCASListingsLatestWithContext is the same as PartnersFCASListingsLatest with a custom context.
+func (c *CoinmarketcapClient) PartnersFCASListingsLatestWithContext(ctx context.Context, request *types.FCASListingsLatestRequest) ([]types.FCASRating, error) {
+	resp, err := c.PartnersFCASListingsLatestWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.performHttpRequest(httpRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
-		var cmcIdMapResponse types.FCASQuotesLatestResponse
-		err = json.Unmarshal(respBody, &cmcIdMapResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return cmcIdMapResponse.Data, nil
-	} else {
-		return nil, errors.New(fmt.Sprintf("PartnersFCASQuotesLatest: %d: %s", resp.StatusCode, resp.Status))
-	}
+	return resp.Data, nil
 }
 
-func prepareHttpRequest(httpRequest *http.Request, request interface{}) error {
-	values, err := query.Values(request)
-	if err != nil {
-		return err
-	}
-
-	httpRequest.Header.Set("Accepts", "application/json")
-	httpRequest.Header.Add("X-CMC_PRO_API_KEY", os.Getenv("CMC_PRO_API_KEY"))
-	httpRequest.URL.RawQuery = values.Encode()
-
-	return nil
+func (c *CoinmarketcapClient) PartnersFCASQuotesLatest(request *types.FCASQuotesLatestRequest) (map[string]*types.FCASRating, error) {
+	return c.PartnersFCASQuotesLatestWithContext(context.Background(), request)
 }
 
-func (c *CoinmarketcapClient) performHttpRequest(req *http.Request) (*http.Response, error) {
-	err := c.limiter.Wait(context.Background())
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.Do(req)
+// PartnersFCASQuotesLatestWithContext is the same as PartnersFCASQuotesLatest with a custom context.
+func (c *CoinmarketcapClient) PartnersFCASQuotesLatestWithContext(ctx context.Context, request *types.FCASQuotesLatestRequest) (map[string]*types.FCASRating, error) {
+	resp, err := c.PartnersFCASQuotesLatestWithResponse(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return resp.Data, nil
 }
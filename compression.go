@@ -0,0 +1,117 @@
+package coinmarketcap_go
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding lists the content encodings the client decodes itself.
+// Setting the header explicitly turns off the transparent gzip support of
+// http.Transport, which would hide the number of bytes on the wire.
+const acceptEncoding = "gzip, deflate"
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// responseBody replaces the body of a response, decoding its content
+// encoding and counting the bytes received and decoded.
+type responseBody struct {
+	io.Reader
+	body    io.ReadCloser
+	wire    *countingReader
+	decoded *countingReader
+	closer  io.Closer
+}
+
+func (b *responseBody) Close() error {
+	if b.closer != nil {
+		b.closer.Close()
+	}
+	return b.body.Close()
+}
+
+// bytes returns the number of bytes read so far before and after decoding.
+func (b *responseBody) bytes() (wire int64, decoded int64) {
+	return b.wire.n, b.decoded.n
+}
+
+// decodeResponse replaces the body of resp with a *responseBody. Like
+// http.Transport, it removes the Content-Encoding and Content-Length headers
+// of decoded responses and marks them as Uncompressed.
+func decodeResponse(resp *http.Response) error {
+	wire := &countingReader{reader: resp.Body}
+	body := &responseBody{body: resp.Body, wire: wire}
+
+	var reader io.Reader = wire
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(wire)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("decoding gzip response: %w", err)
+		}
+		if err == nil {
+			reader, body.closer = gz, gz
+		}
+	case "deflate":
+		// deflate is meant to be zlib wrapped, but some servers send raw
+		// deflate data instead
+		buffered := bufio.NewReader(wire)
+		header, _ := buffered.Peek(2)
+		if isZlibHeader(header) {
+			zr, err := zlib.NewReader(buffered)
+			if err != nil {
+				return fmt.Errorf("decoding deflate response: %w", err)
+			}
+			reader, body.closer = zr, zr
+		} else {
+			fr := flate.NewReader(buffered)
+			reader, body.closer = fr, fr
+		}
+	default:
+		return fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	if encoding != "" && encoding != "identity" {
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	body.decoded = &countingReader{reader: reader}
+	body.Reader = body.decoded
+	resp.Body = body
+	return nil
+}
+
+// isZlibHeader reports whether header is the start of a zlib stream.
+func isZlibHeader(header []byte) bool {
+	return len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// recordBytes sets the byte counters of call from its response body.
+func recordBytes(call *Call) {
+	if call.HTTPResponse == nil {
+		return
+	}
+	if body, ok := call.HTTPResponse.Body.(*responseBody); ok {
+		call.WireBytes, call.DecodedBytes = body.bytes()
+	}
+}
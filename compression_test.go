@@ -0,0 +1,119 @@
+package coinmarketcap_go
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func compress(t testing.TB, encoding string, data []byte) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	default:
+		return data
+	}
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestCoinmarketcapClient_RequestHeaders(t *testing.T) {
+	var header http.Header
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"status":{},"data":[]}`))
+	})
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", header.Get("Accept"))
+	assert.Empty(t, header.Get("Accepts"))
+	assert.Equal(t, "gzip, deflate", header.Get("Accept-Encoding"))
+	assert.Equal(t, DefaultUserAgent, header.Get("User-Agent"))
+}
+
+func TestCoinmarketcapClient_CompressedResponses(t *testing.T) {
+	payload := listingsPayload(20)
+	for _, encoding := range []string{"", "gzip", "deflate", "raw-deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			body := compress(t, encoding, payload)
+			c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if encoding == "raw-deflate" {
+					w.Header().Set("Content-Encoding", "deflate")
+				} else if encoding != "" {
+					w.Header().Set("Content-Encoding", encoding)
+				}
+				w.Write(body)
+			})
+
+			response, err := c.CryptocurrencyListingsLatestWithResponse(context.Background(), &types.CryptocurrencyListingsLatestRequest{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Len(t, response.Data, 20)
+			assert.Equal(t, int64(len(body)), response.WireBytes)
+			assert.Equal(t, int64(len(payload)), response.DecodedBytes)
+			assert.Empty(t, response.Header.Get("Content-Encoding"))
+
+			count := 0
+			err = c.CryptocurrencyListingsLatestStream(context.Background(), &types.CryptocurrencyListingsLatestRequest{}, func(types.CryptocurrencyListing) error {
+				count++
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 20, count)
+		})
+	}
+}
+
+func TestCoinmarketcapClient_CompressedErrorResponse(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(compress(t, "gzip", []byte(`{"status":{"error_code":400,"error_message":"Invalid value for \"id\""}}`)))
+	})
+
+	var call *Call
+	c.middleware = append(c.middleware, MiddlewareFunc(func(next Handler) Handler {
+		return func(ctx context.Context, c *Call) error {
+			call = c
+			return next(ctx, c)
+		}
+	}))
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, `Invalid value for "id"`, apiErr.ErrorMessage)
+	}
+	assert.NotZero(t, call.WireBytes)
+	assert.NotZero(t, call.DecodedBytes)
+}
+
+func TestCoinmarketcapClient_UnsupportedContentEncoding(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		w.Write([]byte("compressed"))
+	})
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unsupported content encoding "br"`)
+	}
+}
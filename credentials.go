@@ -0,0 +1,107 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultApiKeyEnv is the environment variable the client reads the API key
+// from when no credential provider is configured.
+const DefaultApiKeyEnv = "CMC_PRO_API_KEY"
+
+// ErrMissingApiKey is returned when a credential provider has no key to offer.
+var ErrMissingApiKey = errors.New("coinmarketcap: missing api key")
+
+// CredentialProvider supplies the API key for each request. It is consulted
+// on every call, so implementations may rotate keys at runtime.
+type CredentialProvider interface {
+	ApiKey(ctx context.Context) (string, error)
+}
+
+// StaticApiKey is a fixed API key. Its String method is redacted so the key
+// does not end up in logs when the provider is printed.
+type StaticApiKey string
+
+func (k StaticApiKey) ApiKey(ctx context.Context) (string, error) {
+	if k == "" {
+		return "", ErrMissingApiKey
+	}
+	return string(k), nil
+}
+
+func (k StaticApiKey) String() string {
+	return "StaticApiKey(REDACTED)"
+}
+
+func (k StaticApiKey) GoString() string {
+	return k.String()
+}
+
+// EnvApiKey reads the API key from the named environment variable on each call.
+type EnvApiKey string
+
+func (e EnvApiKey) ApiKey(ctx context.Context) (string, error) {
+	key := os.Getenv(string(e))
+	if key == "" {
+		return "", fmt.Errorf("%w: environment variable %s is empty", ErrMissingApiKey, string(e))
+	}
+	return key, nil
+}
+
+// CredentialFunc adapts an ordinary function to a CredentialProvider.
+type CredentialFunc func(ctx context.Context) (string, error)
+
+func (f CredentialFunc) ApiKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// FileApiKey reads the API key from a file and re-reads it whenever the
+// file's modification time or size changes. Surrounding whitespace is trimmed.
+type FileApiKey struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+	size    int64
+}
+
+func NewFileApiKey(path string) *FileApiKey {
+	return &FileApiKey{path: path}
+}
+
+func (f *FileApiKey) ApiKey(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key == "" || !info.ModTime().Equal(f.modTime) || info.Size() != f.size {
+		content, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return "", err
+		}
+
+		f.key = strings.TrimSpace(string(content))
+		f.modTime = info.ModTime()
+		f.size = info.Size()
+	}
+
+	if f.key == "" {
+		return "", fmt.Errorf("%w: file %s is empty", ErrMissingApiKey, f.path)
+	}
+	return f.key, nil
+}
+
+func (f *FileApiKey) String() string {
+	return fmt.Sprintf("FileApiKey(%s)", f.path)
+}
@@ -0,0 +1,99 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticApiKey(t *testing.T) {
+	key, err := StaticApiKey("secret").ApiKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", key)
+
+	_, err = StaticApiKey("").ApiKey(context.Background())
+	assert.True(t, errors.Is(err, ErrMissingApiKey))
+
+	assert.NotContains(t, fmt.Sprintf("%v %+v %#v", StaticApiKey("secret"), StaticApiKey("secret"), StaticApiKey("secret")), "secret")
+}
+
+func TestEnvApiKey(t *testing.T) {
+	os.Setenv("CMC_TEST_API_KEY", "from-env")
+	defer os.Unsetenv("CMC_TEST_API_KEY")
+
+	key, err := EnvApiKey("CMC_TEST_API_KEY").ApiKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", key)
+
+	_, err = EnvApiKey("CMC_TEST_UNSET_API_KEY").ApiKey(context.Background())
+	assert.True(t, errors.Is(err, ErrMissingApiKey))
+}
+
+func TestFileApiKey_ReloadsOnChange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "api_key")
+	if err := ioutil.WriteFile(path, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewFileApiKey(path)
+	key, err := provider.ApiKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first", key)
+
+	if err := ioutil.WriteFile(path, []byte("second-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err = provider.ApiKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "second-key", key)
+}
+
+func TestCoinmarketcapClient_SetCredentials(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	calls := 0
+
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, keys[calls], r.Header.Get("X-CMC_PRO_API_KEY"))
+		calls++
+		w.Write([]byte(`{"status":{"error_code":0},"data":[]}`))
+	})
+	c.SetCredentials(CredentialFunc(func(ctx context.Context) (string, error) {
+		return keys[calls], nil
+	}))
+
+	for range keys {
+		_, err := c.FiatMap(&types.FiatMapRequest{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestCoinmarketcapClient_CredentialError(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not be sent without an api key")
+	})
+	c.SetCredentials(StaticApiKey(""))
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrMissingApiKey))
+}
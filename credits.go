@@ -0,0 +1,218 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// ErrCreditBudgetExceeded is matched by errors.Is when a call is refused
+// because it would exceed a configured credit budget or the credit quota of
+// the API plan.
+var ErrCreditBudgetExceeded = errors.New("coinmarketcap: credit budget exceeded")
+
+// CreditBudgetError is returned before a call is made when the daily or
+// monthly credit budget set with WithCreditBudget, or the respective quota of
+// the plan, has been used up. Every call costs at least one credit, so the
+// budget is considered exceeded once used+1 > budget. Waiting would not help
+// before the window resets at Reset.
+type CreditBudgetError struct {
+	//Either "day" or "month".
+	Window string
+	Budget int
+	Used   int
+	Reset  time.Time
+
+	//Whether Budget is the quota of the plan rather than a budget set with
+	//WithCreditBudget. Exhausted plan quotas also match ErrRateLimited.
+	Quota bool
+}
+
+func (e *CreditBudgetError) Error() string {
+	limit := "budget"
+	if e.Quota {
+		limit = "plan quota"
+	}
+	return fmt.Sprintf("%s: %d of %d credits of the %s used this %s, resets at %s", ErrCreditBudgetExceeded, e.Used, e.Budget, limit, e.Window, e.Reset.Format(time.RFC3339))
+}
+
+func (e *CreditBudgetError) Is(target error) bool {
+	return target == ErrCreditBudgetExceeded || e.Quota && target == ErrRateLimited
+}
+
+// CreditUsage is a snapshot of the API call credits used by a client.
+// Windows follow the API's quota periods and reset at the start of each
+// UTC minute, day and calendar month.
+type CreditUsage struct {
+	Total      int
+	ByEndpoint map[string]int
+	Minute     int
+	Day        int
+	Month      int
+}
+
+// CreditCallback is invoked after every call that reported its credit count.
+type CreditCallback func(endpoint string, credits int, usage CreditUsage)
+
+// WithCreditBudget refuses calls with a *CreditBudgetError once the daily or
+// monthly budget is used up. Zero disables the respective limit.
+func WithCreditBudget(daily, monthly int) Option {
+	return func(c *CoinmarketcapClient) error {
+		if daily < 0 || monthly < 0 {
+			return errors.New("credit budget must not be negative")
+		}
+		c.credits.mu.Lock()
+		c.credits.dailyBudget = daily
+		c.credits.monthlyBudget = monthly
+		c.credits.mu.Unlock()
+		return nil
+	}
+}
+
+// WithCreditCallback registers a callback receiving the credit count of
+// every call. It is invoked synchronously, so it should return quickly.
+func WithCreditCallback(callback CreditCallback) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.credits.mu.Lock()
+		c.credits.callback = callback
+		c.credits.mu.Unlock()
+		return nil
+	}
+}
+
+// CreditUsage returns the credits used by the client so far.
+func (c *CoinmarketcapClient) CreditUsage() CreditUsage {
+	return c.credits.usage()
+}
+
+type creditWindow struct {
+	start time.Time
+	used  int
+}
+
+func (w *creditWindow) roll(start time.Time) {
+	if !w.start.Equal(start) {
+		w.start = start
+		w.used = 0
+	}
+}
+
+type creditTracker struct {
+	mu            sync.Mutex
+	now           func() time.Time
+	total         int
+	byEndpoint    map[string]int
+	minute        creditWindow
+	day           creditWindow
+	month         creditWindow
+	dailyBudget   int
+	monthlyBudget int
+	dailyQuota    int
+	monthlyQuota  int
+	callback      CreditCallback
+}
+
+// setQuota sets the daily and monthly credit quotas of the plan, zero
+// disables the respective quota.
+func (t *creditTracker) setQuota(limits types.PlanLimits) {
+	t.mu.Lock()
+	t.dailyQuota = limits.DailyCredits
+	t.monthlyQuota = limits.MonthlyCredits
+	t.mu.Unlock()
+}
+
+// roll starts new windows if the current ones have passed. Must be called with mu held.
+func (t *creditTracker) roll() {
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+
+	utc := now().UTC()
+	t.minute.roll(utc.Truncate(time.Minute))
+	t.day.roll(time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC))
+	t.month.roll(time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (t *creditTracker) checkBudget() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.roll()
+	day := &CreditBudgetError{Window: "day", Used: t.day.used, Reset: t.day.start.AddDate(0, 0, 1)}
+	if day.exceeds(t.dailyBudget, t.dailyQuota) {
+		return day
+	}
+	month := &CreditBudgetError{Window: "month", Used: t.month.used, Reset: t.month.start.AddDate(0, 1, 0)}
+	if month.exceeds(t.monthlyBudget, t.monthlyQuota) {
+		return month
+	}
+
+	return nil
+}
+
+// exceeds reports whether e.Used exhausts the budget or the quota, zero
+// meaning no limit, and sets e.Budget and e.Quota accordingly.
+func (e *CreditBudgetError) exceeds(budget, quota int) bool {
+	switch {
+	case budget > 0 && e.Used+1 > budget:
+		e.Budget = budget
+	case quota > 0 && e.Used+1 > quota:
+		e.Budget, e.Quota = quota, true
+	default:
+		return false
+	}
+	return true
+}
+
+func (t *creditTracker) record(endpoint string, credits int) {
+	if credits <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	t.roll()
+	if t.byEndpoint == nil {
+		t.byEndpoint = make(map[string]int)
+	}
+	t.total += credits
+	t.byEndpoint[endpoint] += credits
+	t.minute.used += credits
+	t.day.used += credits
+	t.month.used += credits
+
+	callback := t.callback
+	usage := t.snapshot()
+	t.mu.Unlock()
+
+	if callback != nil {
+		callback(endpoint, credits, usage)
+	}
+}
+
+func (t *creditTracker) usage() CreditUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.roll()
+	return t.snapshot()
+}
+
+// snapshot copies the current state. Must be called with mu held.
+func (t *creditTracker) snapshot() CreditUsage {
+	byEndpoint := make(map[string]int, len(t.byEndpoint))
+	for endpoint, credits := range t.byEndpoint {
+		byEndpoint[endpoint] = credits
+	}
+
+	return CreditUsage{
+		Total:      t.total,
+		ByEndpoint: byEndpoint,
+		Minute:     t.minute.used,
+		Day:        t.day.used,
+		Month:      t.month.used,
+	}
+}
@@ -0,0 +1,119 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinmarketcapClient_CreditUsage(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/fiat/map":
+			w.Write([]byte(`{"status":{"credit_count":1},"data":[]}`))
+		case "/v1/cryptocurrency/listings/latest":
+			w.Write([]byte(`{"status":{"credit_count":3},"data":[]}`))
+		}
+	})
+
+	var callbackCredits []int
+	WithCreditCallback(func(endpoint string, credits int, usage CreditUsage) {
+		callbackCredits = append(callbackCredits, credits)
+	})(c)
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.NoError(t, err)
+	_, err = c.CryptocurrencyListingsLatest(&types.CryptocurrencyListingsLatestRequest{Limit: 300})
+	assert.NoError(t, err)
+
+	usage := c.CreditUsage()
+	assert.Equal(t, 4, usage.Total)
+	assert.Equal(t, 4, usage.Day)
+	assert.Equal(t, 4, usage.Month)
+	assert.Equal(t, map[string]int{"FiatMap": 1, "CryptocurrencyListingsLatest": 3}, usage.ByEndpoint)
+	assert.Equal(t, []int{1, 3}, callbackCredits)
+}
+
+func TestCoinmarketcapClient_CreditBudget(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":{"credit_count":1},"data":[]}`))
+	})
+	WithCreditBudget(2, 0)(c)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.FiatMap(&types.FiatMapRequest{})
+		assert.NoError(t, err)
+	}
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrCreditBudgetExceeded), "unexpected error: %v", err)
+
+	var budgetErr *CreditBudgetError
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.Equal(t, "day", budgetErr.Window)
+		assert.Equal(t, 2, budgetErr.Used)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestCreditTracker_Windows(t *testing.T) {
+	now := time.Date(2020, 7, 31, 23, 59, 30, 0, time.UTC)
+	tracker := &creditTracker{now: func() time.Time { return now }, monthlyBudget: 10}
+
+	tracker.record("FiatMap", 5)
+	assert.Equal(t, CreditUsage{Total: 5, ByEndpoint: map[string]int{"FiatMap": 5}, Minute: 5, Day: 5, Month: 5}, tracker.usage())
+
+	now = now.Add(time.Minute)
+	tracker.record("FiatMap", 2)
+	usage := tracker.usage()
+	assert.Equal(t, 7, usage.Total)
+	assert.Equal(t, 2, usage.Minute)
+	assert.Equal(t, 2, usage.Day)
+	assert.Equal(t, 2, usage.Month)
+
+	tracker.record("FiatMap", 8)
+	assert.True(t, errors.Is(tracker.checkBudget(), ErrCreditBudgetExceeded))
+}
+
+func TestCreditTracker_PlanQuota(t *testing.T) {
+	now := time.Date(2020, 7, 15, 12, 0, 0, 0, time.UTC)
+	tracker := &creditTracker{now: func() time.Time { return now }}
+	tracker.setQuota(types.PlanLimits{DailyCredits: 3, MonthlyCredits: 5})
+
+	assert.NoError(t, tracker.checkBudget())
+	tracker.record("FiatMap", 3)
+
+	err := tracker.checkBudget()
+	var budgetErr *CreditBudgetError
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.Equal(t, "day", budgetErr.Window)
+		assert.True(t, budgetErr.Quota)
+		assert.Equal(t, time.Date(2020, 7, 16, 0, 0, 0, 0, time.UTC), budgetErr.Reset)
+	}
+	assert.True(t, errors.Is(err, ErrRateLimited))
+
+	// the daily window resets at midnight UTC, the monthly one keeps counting
+	now = now.Add(12 * time.Hour)
+	assert.NoError(t, tracker.checkBudget())
+	tracker.record("FiatMap", 2)
+
+	err = tracker.checkBudget()
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.Equal(t, "month", budgetErr.Window)
+	}
+
+	// a lower budget is reported as such
+	tracker.dailyBudget = 1
+	err = tracker.checkBudget()
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.Equal(t, "day", budgetErr.Window)
+		assert.False(t, budgetErr.Quota)
+		assert.False(t, errors.Is(err, ErrRateLimited))
+	}
+}
@@ -0,0 +1,61 @@
+package coinmarketcap_go
+
+import (
+	"context"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// The Decimal methods decode prices, volumes, market caps and supplies into
+// types.Decimal, keeping the exact digits sent by the API. Use
+// types.Decimal.Float64 to convert single values back to float64.
+
+// CryptocurrencyListingsHistoricalDecimal is the same as CryptocurrencyListingsHistoricalWithContext with exact decimal values.
+func (c *CoinmarketcapClient) CryptocurrencyListingsHistoricalDecimal(ctx context.Context, request *types.CryptocurrencyListingsHistoricalRequest) ([]types.CryptocurrencyListingDecimal, error) {
+	resp, err := execute(ctx, c, cryptocurrencyListingsHistoricalDecimalEndpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// CryptocurrencyListingsLatestDecimal is the same as CryptocurrencyListingsLatestWithContext with exact decimal values.
+func (c *CoinmarketcapClient) CryptocurrencyListingsLatestDecimal(ctx context.Context, request *types.CryptocurrencyListingsLatestRequest) ([]types.CryptocurrencyListingDecimal, error) {
+	resp, err := execute(ctx, c, cryptocurrencyListingsLatestDecimalEndpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// CryptocurrencyOHLCVHistoricalDecimal is the same as CryptocurrencyOHLCVHistoricalWithContext with exact decimal values.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVHistoricalDecimal(ctx context.Context, request *types.CryptocurrencyOHLCVHistoricalRequest) (map[string]*types.OHLCVHistoricalResultDecimal, error) {
+	resp, err := execute(ctx, c, cryptocurrencyOHLCVHistoricalDecimalEndpoint, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// CryptocurrencyOHLCVLatestDecimal is the same as CryptocurrencyOHLCVLatestWithContext with exact decimal values.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVLatestDecimal(ctx context.Context, request *types.CryptocurrencyOHLCVLatestRequest) (map[string]*types.CryptocurrencyOHLCVDecimal, error) {
+	resp, err := executeChunked(ctx, c, cryptocurrencyOHLCVLatestDecimalEndpoint, request)
+	if resp == nil {
+		return nil, err
+	}
+
+	return resp.Data, err
+}
+
+// CryptocurrencyQuotesLatestDecimal is the same as CryptocurrencyQuotesLatestWithContext with exact decimal values.
+func (c *CoinmarketcapClient) CryptocurrencyQuotesLatestDecimal(ctx context.Context, request *types.CryptocurrencyQuotesLatestRequest) (map[string]types.CryptocurrencyQuoteDecimal, error) {
+	resp, err := executeChunked(ctx, c, cryptocurrencyQuotesLatestDecimalEndpoint, request)
+	if resp == nil {
+		return nil, err
+	}
+
+	return resp.Data, err
+}
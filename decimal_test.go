@@ -0,0 +1,59 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal(t *testing.T) {
+	var d types.Decimal
+	assert.NoError(t, json.Unmarshal([]byte(`0.000000000001234567890123456789`), &d))
+	assert.Equal(t, "0.000000000001234567890123456789", d.String())
+	exact, _ := new(big.Rat).SetString("1234567890123456789/1000000000000000000000000000000")
+	assert.Equal(t, 0, d.Rat().Cmp(exact))
+	assert.Equal(t, 1.234567890123456789e-12, d.Float64())
+	_, isExact := d.Float64Exact()
+	assert.False(t, isExact)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"1.5e-12"`), &d))
+	assert.Equal(t, 1.5e-12, d.Float64())
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.True(t, d.IsZero())
+	assert.Equal(t, "0", d.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`"1/3"`), &d))
+	_, err := types.NewDecimal("0x10")
+	assert.Error(t, err)
+
+	supply, _ := types.NewDecimal("999999999999999999999.000000001")
+	smaller, _ := types.NewDecimal("999999999999999999999")
+	assert.Equal(t, 1, supply.Cmp(smaller))
+	assert.Equal(t, supply.Float64(), smaller.Float64())
+
+	encoded, err := json.Marshal(struct{ Price types.Decimal }{supply})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Price":999999999999999999999.000000001}`, string(encoded))
+}
+
+func TestCoinmarketcapClient_QuotesLatestDecimal(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":{},"data":{"1":{"id":1,"circulating_supply":589000000000000000.123456789,"max_supply":null,` +
+			`"quote":{"USD":{"price":0.000000000001234567,"percent_change_24h":-1.5}}}}}`))
+	})
+
+	quotes, err := c.CryptocurrencyQuotesLatestDecimal(context.Background(), &types.CryptocurrencyQuotesLatestRequest{Id: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "589000000000000000.123456789", quotes["1"].CirculatingSupply.String())
+	assert.Equal(t, "0.000000000001234567", quotes["1"].Quote["USD"].Price.String())
+	assert.Equal(t, -1.5, *quotes["1"].Quote["USD"].PercentChange24H)
+	assert.Nil(t, quotes["1"].MaxSupply)
+}
@@ -0,0 +1,150 @@
+package coinmarketcap_go
+
+import (
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// Default cache TTLs follow the update frequency of the endpoints: latest
+// market data changes every minute, maps and metadata rarely, and historical
+// data not at all once the period has passed.
+
+// ------ Cryptocurrency ------ //
+
+var cryptocurrencyIdMapEndpoint = endpoint[types.CryptocurrencyMapRequest, []types.Cryptocurrency]{
+	name: "CryptocurrencyIdMap",
+	path: "/v1/cryptocurrency/map",
+	ttl:  time.Hour,
+}
+
+var cryptocurrencyInfoEndpoint = endpoint[types.CryptocurrencyInfoRequest, map[string]*types.CryptocurrencyInfo]{
+	name:  "CryptocurrencyInfo",
+	path:  "/v1/cryptocurrency/info",
+	ttl:   time.Hour,
+	lists: []string{"id", "slug", "symbol"},
+}
+
+var cryptocurrencyListingsHistoricalEndpoint = endpoint[types.CryptocurrencyListingsHistoricalRequest, []types.CryptocurrencyListing]{
+	name: "CryptocurrencyListingsHistorical",
+	path: "/v1/cryptocurrency/listings/historical",
+	ttl:  24 * time.Hour,
+}
+
+var cryptocurrencyListingsLatestEndpoint = endpoint[types.CryptocurrencyListingsLatestRequest, []types.CryptocurrencyListing]{
+	name: "CryptocurrencyListingsLatest",
+	path: "/v1/cryptocurrency/listings/latest",
+	ttl:  time.Minute,
+}
+
+var cryptocurrencyOHLCVHistoricalEndpoint = endpoint[types.CryptocurrencyOHLCVHistoricalRequest, map[string]*types.OHLCVHistoricalResult]{
+	name: "CryptocurrencyOHLCVHistorical",
+	path: "/v1/cryptocurrency/ohlcv/historical",
+	ttl:  5 * time.Minute,
+}
+
+var cryptocurrencyOHLCVLatestEndpoint = endpoint[types.CryptocurrencyOHLCVLatestRequest, map[string]*types.CryptocurrencyOHLCV]{
+	name:  "CryptocurrencyOHLCVLatest",
+	path:  "/v1/cryptocurrency/ohlcv/latest",
+	ttl:   time.Minute,
+	lists: []string{"id", "symbol"},
+}
+
+var cryptocurrencyQuotesLatestEndpoint = endpoint[types.CryptocurrencyQuotesLatestRequest, map[string]types.CryptocurrencyQuote]{
+	name:  "CryptocurrencyQuotesLatest",
+	path:  "/v1/cryptocurrency/quotes/latest",
+	ttl:   time.Minute,
+	lists: []string{"id", "slug", "symbol"},
+}
+
+var cryptocurrencyPricePerformanceStatsEndpoint = endpoint[types.CryptocurrencyPricePerformanceStatsRequest, map[string]*types.PricePerformanceStats]{
+	name: "CryptocurrencyPricePerformanceStats",
+	path: "/v1/cryptocurrency/price-performance-stats/latest",
+	ttl:  time.Minute,
+}
+
+// The Decimal endpoints share the paths of their float counterparts but are
+// named separately, so coalesced calls never mix the two data types.
+
+var cryptocurrencyListingsHistoricalDecimalEndpoint = endpoint[types.CryptocurrencyListingsHistoricalRequest, []types.CryptocurrencyListingDecimal]{
+	name: "CryptocurrencyListingsHistoricalDecimal",
+	path: cryptocurrencyListingsHistoricalEndpoint.path,
+	ttl:  cryptocurrencyListingsHistoricalEndpoint.ttl,
+}
+
+var cryptocurrencyListingsLatestDecimalEndpoint = endpoint[types.CryptocurrencyListingsLatestRequest, []types.CryptocurrencyListingDecimal]{
+	name: "CryptocurrencyListingsLatestDecimal",
+	path: cryptocurrencyListingsLatestEndpoint.path,
+	ttl:  cryptocurrencyListingsLatestEndpoint.ttl,
+}
+
+var cryptocurrencyOHLCVHistoricalDecimalEndpoint = endpoint[types.CryptocurrencyOHLCVHistoricalRequest, map[string]*types.OHLCVHistoricalResultDecimal]{
+	name: "CryptocurrencyOHLCVHistoricalDecimal",
+	path: cryptocurrencyOHLCVHistoricalEndpoint.path,
+	ttl:  cryptocurrencyOHLCVHistoricalEndpoint.ttl,
+}
+
+var cryptocurrencyOHLCVLatestDecimalEndpoint = endpoint[types.CryptocurrencyOHLCVLatestRequest, map[string]*types.CryptocurrencyOHLCVDecimal]{
+	name:  "CryptocurrencyOHLCVLatestDecimal",
+	path:  cryptocurrencyOHLCVLatestEndpoint.path,
+	ttl:   cryptocurrencyOHLCVLatestEndpoint.ttl,
+	lists: cryptocurrencyOHLCVLatestEndpoint.lists,
+}
+
+var cryptocurrencyQuotesLatestDecimalEndpoint = endpoint[types.CryptocurrencyQuotesLatestRequest, map[string]types.CryptocurrencyQuoteDecimal]{
+	name:  "CryptocurrencyQuotesLatestDecimal",
+	path:  cryptocurrencyQuotesLatestEndpoint.path,
+	ttl:   cryptocurrencyQuotesLatestEndpoint.ttl,
+	lists: cryptocurrencyQuotesLatestEndpoint.lists,
+}
+
+// ------ Fiat ------ //
+
+var fiatMapEndpoint = endpoint[types.FiatMapRequest, []types.Fiat]{
+	name: "FiatMap",
+	path: "/v1/fiat/map",
+	ttl:  24 * time.Hour,
+}
+
+// ------ Exchange ------ //
+
+var exchangeInfoEndpoint = endpoint[types.ExchangeInfoRequest, map[string]*types.ExchangeInfo]{
+	name:  "ExchangeInfo",
+	path:  "/v1/exchange/info",
+	ttl:   time.Hour,
+	lists: []string{"id", "slug"},
+}
+
+var exchangeIdMapEndpoint = endpoint[types.ExchangeIdMapRequest, []types.Exchange]{
+	name: "ExchangeIdMap",
+	path: "/v1/exchange/map",
+	ttl:  time.Hour,
+}
+
+// ------ Global-Metrics ------ //
+
+var globalMetricsQuotesLatestEndpoint = endpoint[types.GlobalMetricsQuotesLatestRequest, types.GlobalMetricsQuotesLatest]{
+	name: "GlobalMetricsQuotesLatest",
+	path: "/v1/global-metrics/quotes/latest",
+	ttl:  time.Minute,
+}
+
+var globalMetricsQuotesHistoricalEndpoint = endpoint[types.GlobalMetricsQuotesHistoricalRequest, types.GlobalMetricsQuotesHistorical]{
+	name: "GlobalMetricsQuotesHistorical",
+	path: "/v1/global-metrics/quotes/historical",
+	ttl:  5 * time.Minute,
+}
+
+// ------ Partners ------ //
+
+var partnersFCASListingsLatestEndpoint = endpoint[types.FCASListingsLatestRequest, []types.FCASRating]{
+	name: "PartnersFCASListingsLatest",
+	path: "/v1/partners/flipside-crypto/fcas/listings/latest",
+	ttl:  time.Hour,
+}
+
+var partnersFCASQuotesLatestEndpoint = endpoint[types.FCASQuotesLatestRequest, map[string]*types.FCASRating]{
+	name: "PartnersFCASQuotesLatest",
+	path: "/v1/partners/flipside-crypto/fcas/quotes/latest",
+	ttl:  time.Hour,
+}
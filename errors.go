@@ -0,0 +1,166 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// ErrCanceled is matched by errors.Is for every error caused by a canceled
+// or expired context, whether the request was still waiting on the rate
+// limiter or already in flight.
+var ErrCanceled = errors.New("coinmarketcap: request canceled")
+
+// CanceledError is returned when the context of a call is done before the
+// call completes. Err holds the underlying context error, so
+// errors.Is(err, context.DeadlineExceeded) works as well.
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCanceled, e.Err)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CanceledError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+// contextError converts err into a CanceledError if ctx is done. Timeouts of
+// the http.Client or its transport also match context.DeadlineExceeded, but
+// are ordinary transport errors as long as ctx is live.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &CanceledError{Err: ctxErr}
+	}
+
+	return err
+}
+
+// limiterError converts an error returned by rate.Limiter.Wait. The limiter
+// refuses to wait when the reservation would outlive the context deadline,
+// before the context itself expires, so that case is reported as a deadline
+// error too.
+func limiterError(ctx context.Context, err error) error {
+	if _, ok := ctx.Deadline(); ok && ctx.Err() == nil {
+		return &CanceledError{Err: context.DeadlineExceeded}
+	}
+
+	return contextError(ctx, err)
+}
+
+// Error codes returned by the API in the error_code field of the status object.
+// https://coinmarketcap.com/api/documentation/v1/#section/Errors-and-Rate-Limits
+const (
+	ErrorCodeApiKeyInvalid           = 1001
+	ErrorCodeApiKeyMissing           = 1002
+	ErrorCodePlanRequiresPayment     = 1003
+	ErrorCodePlanPaymentExpired      = 1004
+	ErrorCodeApiKeyRequired          = 1005
+	ErrorCodePlanNotAuthorized       = 1006
+	ErrorCodeApiKeyDisabled          = 1007
+	ErrorCodeMinuteRateLimitReached  = 1008
+	ErrorCodeDailyRateLimitReached   = 1009
+	ErrorCodeMonthlyRateLimitReached = 1010
+	ErrorCodeIpRateLimitReached      = 1011
+)
+
+// Sentinel errors an APIError can be matched against with errors.Is.
+var (
+	ErrRateLimited      = errors.New("coinmarketcap: rate limited")
+	ErrUnauthorized     = errors.New("coinmarketcap: unauthorized")
+	ErrPlanNotSupported = errors.New("coinmarketcap: plan not supported")
+	ErrInvalidParameter = types.ErrInvalidParameter
+)
+
+// APIError is returned when the API responds with an error status.
+// The details are taken from the status object of the response body when
+// one is present.
+type APIError struct {
+	//Name of the client method that made the call, e.g. "CryptocurrencyQuotesLatest".
+	Endpoint string
+
+	//HTTP status code of the response.
+	StatusCode int
+
+	//The error_code of the response status, or the HTTP status code if the body could not be decoded.
+	ErrorCode int
+
+	//The error_message of the response status, or the HTTP status text if the body could not be decoded.
+	ErrorMessage string
+
+	//Number of API call credits charged for the failed call.
+	CreditCount int
+
+	//Number of attempts made before giving up, more than 1 if the call was retried.
+	Attempts int
+
+	response *http.Response
+	status   *types.ResponseStatus
+}
+
+func (e *APIError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("%s: %d: %d: %s (after %d attempts)", e.Endpoint, e.StatusCode, e.ErrorCode, e.ErrorMessage, e.Attempts)
+	}
+	return fmt.Sprintf("%s: %d: %d: %s", e.Endpoint, e.StatusCode, e.ErrorCode, e.ErrorMessage)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests ||
+			e.ErrorCode >= ErrorCodeMinuteRateLimitReached && e.ErrorCode <= ErrorCodeIpRateLimitReached
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized ||
+			e.ErrorCode == ErrorCodeApiKeyInvalid || e.ErrorCode == ErrorCodeApiKeyMissing ||
+			e.ErrorCode == ErrorCodeApiKeyRequired || e.ErrorCode == ErrorCodeApiKeyDisabled
+	case ErrPlanNotSupported:
+		return e.StatusCode == http.StatusPaymentRequired ||
+			e.ErrorCode == ErrorCodePlanRequiresPayment || e.ErrorCode == ErrorCodePlanPaymentExpired ||
+			e.ErrorCode == ErrorCodePlanNotAuthorized
+	case ErrInvalidParameter:
+		return e.StatusCode == http.StatusBadRequest
+	}
+
+	return false
+}
+
+// newAPIError builds an APIError from an unsuccessful response and closes its body.
+func newAPIError(endpoint string, resp *http.Response) *APIError {
+	defer resp.Body.Close()
+
+	apiErr := &APIError{
+		Endpoint:     endpoint,
+		StatusCode:   resp.StatusCode,
+		response:     resp,
+		ErrorCode:    resp.StatusCode,
+		ErrorMessage: http.StatusText(resp.StatusCode),
+	}
+
+	var envelope struct {
+		Status types.ResponseStatus `json:"status"`
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err == nil && json.Unmarshal(body, &envelope) == nil {
+		if envelope.Status.ErrorCode != 0 {
+			apiErr.ErrorCode = envelope.Status.ErrorCode
+		}
+		if envelope.Status.ErrorMessage != "" {
+			apiErr.ErrorMessage = envelope.Status.ErrorMessage
+		}
+		apiErr.CreditCount = envelope.Status.CreditCount
+		apiErr.status = &envelope.Status
+	}
+
+	return apiErr
+}
@@ -0,0 +1,105 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinmarketcapClient_ContextDeadlineWhileRateLimited(t *testing.T) {
+	c := &CoinmarketcapClient{}
+	if err := c.Init(types.Basic); err != nil {
+		t.Fatal(err)
+	}
+	c.SetCredentials(StaticApiKey("test-key"))
+	// drain the limiter so the next call has to wait for a token
+	assert.True(t, c.limiter.(*Limiter).calls.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.CryptocurrencyIdMapWithContext(ctx, &types.CryptocurrencyMapRequest{})
+	assert.True(t, errors.Is(err, ErrCanceled), "unexpected error: %v", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+}
+
+func TestCoinmarketcapClient_ContextCanceled(t *testing.T) {
+	c := &CoinmarketcapClient{}
+	if err := c.Init(types.Basic); err != nil {
+		t.Fatal(err)
+	}
+	c.SetCredentials(StaticApiKey("test-key"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.FiatMapWithContext(ctx, &types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrCanceled), "unexpected error: %v", err)
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+
+	var canceledErr *CanceledError
+	assert.True(t, errors.As(err, &canceledErr))
+}
+
+func TestContextError_PassesThroughOtherErrors(t *testing.T) {
+	err := errors.New("connection refused")
+	assert.Equal(t, err, contextError(context.Background(), err))
+}
+
+func TestCoinmarketcapClient_APIError(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":{"error_code":1008,"error_message":"You've exceeded your API Key's HTTP request rate limit.","credit_count":0}}`))
+	})
+
+	_, err := c.CryptocurrencyQuotesLatest(&types.CryptocurrencyQuotesLatestRequest{Symbol: "BTC"})
+	assert.True(t, errors.Is(err, ErrRateLimited), "unexpected error: %v", err)
+	assert.False(t, errors.Is(err, ErrUnauthorized))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "CryptocurrencyQuotesLatest", apiErr.Endpoint)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Equal(t, ErrorCodeMinuteRateLimitReached, apiErr.ErrorCode)
+		assert.Equal(t, "You've exceeded your API Key's HTTP request rate limit.", apiErr.ErrorMessage)
+	}
+}
+
+func TestCoinmarketcapClient_APIErrorWithoutBody(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.ErrorCode)
+		assert.Equal(t, http.StatusText(http.StatusBadGateway), apiErr.ErrorMessage)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		err    *APIError
+		target error
+	}{
+		{&APIError{StatusCode: http.StatusUnauthorized, ErrorCode: ErrorCodeApiKeyInvalid}, ErrUnauthorized},
+		{&APIError{StatusCode: http.StatusForbidden, ErrorCode: ErrorCodeApiKeyDisabled}, ErrUnauthorized},
+		{&APIError{StatusCode: http.StatusForbidden, ErrorCode: ErrorCodePlanNotAuthorized}, ErrPlanNotSupported},
+		{&APIError{StatusCode: http.StatusPaymentRequired, ErrorCode: ErrorCodePlanPaymentExpired}, ErrPlanNotSupported},
+		{&APIError{StatusCode: http.StatusTooManyRequests, ErrorCode: ErrorCodeIpRateLimitReached}, ErrRateLimited},
+		{&APIError{StatusCode: http.StatusBadRequest, ErrorCode: http.StatusBadRequest}, ErrInvalidParameter},
+	}
+
+	for _, test := range tests {
+		assert.True(t, errors.Is(test.err, test.target), "%v is not %v", test.err, test.target)
+	}
+
+	assert.False(t, errors.Is(&APIError{StatusCode: http.StatusInternalServerError}, ErrRateLimited))
+}
@@ -1,12 +1,17 @@
 module github.com/drankou/coinmarketcap-go
 
-go 1.14
+go 1.18
 
 require (
 	github.com/google/go-querystring v1.0.0
 	github.com/joho/godotenv v1.3.0
 	github.com/sirupsen/logrus v1.6.0
 	github.com/stretchr/testify v1.2.2
-	golang.org/x/sys v0.0.0-20200323222414-85ca7c5b95cd // indirect
 	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20200323222414-85ca7c5b95cd // indirect
+)
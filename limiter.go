@@ -0,0 +1,40 @@
+package coinmarketcap_go
+
+import (
+	"context"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter throttles calls made by the client. *rate.Limiter satisfies it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Limiter throttles calls to the calls per minute of an API plan. The daily
+// and monthly credit quotas of the plan are enforced by the credit accounting
+// of the client using it, see WithPlan and CreditUsage.
+type Limiter struct {
+	limits types.PlanLimits
+	calls  *rate.Limiter
+}
+
+// NewLimiter creates a Limiter for the given quotas, which may be taken from
+// types.APIPlanLimits or describe a custom contract.
+func NewLimiter(limits types.PlanLimits) *Limiter {
+	return &Limiter{
+		limits: limits,
+		calls:  rate.NewLimiter(limits.RateLimit(), 1),
+	}
+}
+
+// Limits returns the quotas the limiter enforces.
+func (l *Limiter) Limits() types.PlanLimits {
+	return l.limits
+}
+
+// Wait blocks until a call is allowed by the per-minute limit.
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.calls.Wait(ctx)
+}
@@ -0,0 +1,62 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestAPIPlanLimits_AllPlans(t *testing.T) {
+	for plan := types.Free; plan <= types.Enterprise; plan++ {
+		limits, ok := types.APIPlanLimits[plan]
+		if assert.True(t, ok, "missing limits for plan %d", plan) {
+			assert.True(t, limits.CallsPerMinute > 0, "plan %d has no call limit", plan)
+			assert.Equal(t, types.APIRateLimits[plan], limits.RateLimit())
+		}
+	}
+}
+
+func TestCoinmarketcapClient_PlanLimits(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status":{"credit_count":2},"data":[]}`))
+	})
+	if err := WithPlanLimits(types.PlanLimits{CallsPerMinute: 6000, DailyCredits: 4})(c); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.FiatMapWithContext(ctx, &types.FiatMapRequest{})
+		assert.NoError(t, err)
+	}
+
+	_, err := c.FiatMapWithContext(ctx, &types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrCreditBudgetExceeded), "unexpected error: %v", err)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	var budgetErr *CreditBudgetError
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.True(t, budgetErr.Quota)
+		assert.Equal(t, 4, budgetErr.Budget)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestCoinmarketcapClient_SharedRateLimiter(t *testing.T) {
+	c, err := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := c.limiter.(*rate.Limiter)
+	assert.True(t, ok)
+}
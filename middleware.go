@@ -0,0 +1,69 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// Call describes a single API call passing through the middleware chain.
+type Call struct {
+	//Name of the client method, e.g. "CryptocurrencyQuotesLatest".
+	Endpoint string
+
+	//The typed request struct, e.g. *types.CryptocurrencyQuotesLatestRequest.
+	//For calls split into chunks it is the original request, while
+	//HTTPRequest holds the query of the chunk.
+	Request interface{}
+
+	//The prepared HTTP request. Middleware may modify it before calling next.
+	HTTPRequest *http.Request
+
+	//The HTTP response of the last attempt, set once next returns. Its body has
+	//already been consumed and closed at that point.
+	HTTPResponse *http.Response
+
+	//The decoded status object, set once next returns if the API sent one.
+	Status *types.ResponseStatus
+
+	//Size of the body of HTTPResponse as received, i.e. possibly compressed,
+	//and after decoding. Set once next returns.
+	WireBytes    int64
+	DecodedBytes int64
+
+	body []byte
+}
+
+// Handler performs a call, filling in its response fields.
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps the call path of the client. It may inspect or modify the
+// call before and after calling next, or return without calling it at all.
+type Middleware interface {
+	Wrap(next Handler) Handler
+}
+
+// MiddlewareFunc adapts an ordinary function to a Middleware.
+type MiddlewareFunc func(next Handler) Handler
+
+func (f MiddlewareFunc) Wrap(next Handler) Handler {
+	return f(next)
+}
+
+// WithMiddleware appends middleware to the client's chain. The first
+// middleware passed is the outermost one, i.e. it sees the call first.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+// chain wraps handler in the client's middleware.
+func (c *CoinmarketcapClient) chain(handler Handler) Handler {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i].Wrap(handler)
+	}
+	return handler
+}
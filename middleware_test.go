@@ -0,0 +1,94 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinmarketcapClient_MiddlewareOrder(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit-42", r.Header.Get("X-Request-Id"))
+		w.Header().Set("X-Served-By", "stub")
+		w.Write([]byte(`{"status":{"credit_count":1,"elapsed":7},"data":[]}`))
+	})
+
+	var trace []string
+	record := func(name string) Middleware {
+		return MiddlewareFunc(func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				trace = append(trace, name+" before "+call.Endpoint)
+				err := next(ctx, call)
+				trace = append(trace, name+" after")
+				return err
+			}
+		})
+	}
+
+	var seen *Call
+	headers := MiddlewareFunc(func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.HTTPRequest.Header.Set("X-Request-Id", "audit-42")
+			err := next(ctx, call)
+			seen = call
+			return err
+		}
+	})
+	WithMiddleware(record("outer"), record("inner"), headers)(c)
+
+	request := &types.FiatMapRequest{Limit: 5}
+	_, err := c.FiatMap(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"outer before FiatMap", "inner before FiatMap", "inner after", "outer after"}, trace)
+	if assert.NotNil(t, seen) {
+		assert.Equal(t, request, seen.Request)
+		assert.Equal(t, "stub", seen.HTTPResponse.Header.Get("X-Served-By"))
+		assert.Equal(t, 7, seen.Status.Elapsed)
+	}
+}
+
+func TestCoinmarketcapClient_MiddlewareFaultInjection(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not reach the server")
+	})
+
+	injected := errors.New("injected fault")
+	WithMiddleware(MiddlewareFunc(func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			return injected
+		}
+	}))(c)
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.Equal(t, injected, err)
+}
+
+func TestCoinmarketcapClient_MiddlewareSeesAPIErrorStatus(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":{"error_code":400,"error_message":"Invalid value for \"slug\": \"not-a-coin\""}}`))
+	})
+
+	var status *types.ResponseStatus
+	WithMiddleware(MiddlewareFunc(func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+			status = call.Status
+			assert.Equal(t, http.StatusBadRequest, call.HTTPResponse.StatusCode)
+			return err
+		}
+	}))(c)
+
+	_, err := c.CryptocurrencyInfo(&types.CryptocurrencyInfoRequest{Slug: "not-a-coin"})
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+	if assert.NotNil(t, status) {
+		assert.Equal(t, `Invalid value for "slug": "not-a-coin"`, status.ErrorMessage)
+	}
+}
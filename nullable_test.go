@@ -0,0 +1,82 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func servePayload(t *testing.T, file string) func(w http.ResponseWriter, r *http.Request) {
+	payload, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}
+}
+
+func TestCoinmarketcapClient_QuotesLatestNulls(t *testing.T) {
+	c, _ := newTestServer(t, servePayload(t, "testdata/quotes_latest_nulls.json"))
+
+	quotes, err := c.CryptocurrencyQuotesLatest(&types.CryptocurrencyQuotesLatestRequest{Id: "1,1027,7083"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	btc, eth, uni := quotes["1"], quotes["1027"], quotes["7083"]
+
+	maxSupply, ok := types.Value(btc.MaxSupply)
+	assert.True(t, ok)
+	assert.Equal(t, 21000000.0, maxSupply)
+	assert.Nil(t, btc.Platform)
+
+	_, ok = types.Value(eth.MaxSupply)
+	assert.False(t, ok, "null max_supply must not decode as 0")
+	assert.Equal(t, 112069633.3115, types.ValueOr(eth.TotalSupply, 0))
+	assert.Equal(t, 0.0, *eth.Quote["USD"].PercentChange1H, "0 must stay distinguishable from null")
+
+	assert.Nil(t, uni.TotalSupply)
+	assert.Equal(t, -1.0, types.ValueOr(uni.Quote["USD"].PercentChange24H, -1))
+	assert.Nil(t, uni.Quote["USD"].PercentChange7D)
+	if assert.NotNil(t, uni.Platform) {
+		assert.Equal(t, "0x1f9840a85d5af5bf1d1762f925bdaddc4201f984", uni.Platform.TokenAddress)
+	}
+}
+
+func TestCoinmarketcapClient_InfoNulls(t *testing.T) {
+	c, _ := newTestServer(t, servePayload(t, "testdata/info_nulls.json"))
+
+	infos, err := c.CryptocurrencyInfo(&types.CryptocurrencyInfoRequest{Symbol: "BTC,BSV,USDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, infos["BTC"].Notice)
+	assert.Nil(t, infos["BTC"].Platform)
+	if assert.NotNil(t, infos["BSV"].Notice) {
+		assert.Equal(t, "", *infos["BSV"].Notice)
+	}
+	assert.Equal(t, "Tether is issued on multiple platforms.", types.ValueOr(infos["USDT"].Notice, ""))
+	if assert.NotNil(t, infos["USDT"].Platform) {
+		assert.Equal(t, "OMNI", infos["USDT"].Platform.Symbol)
+	}
+}
+
+func TestCoinmarketcapClient_QuotesLatestDecimalNulls(t *testing.T) {
+	c, _ := newTestServer(t, servePayload(t, "testdata/quotes_latest_nulls.json"))
+
+	quotes, err := c.CryptocurrencyQuotesLatestDecimal(context.Background(), &types.CryptocurrencyQuotesLatestRequest{Id: "1,1027,7083"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "21000000", quotes["1"].MaxSupply.String())
+	assert.Nil(t, quotes["1027"].MaxSupply)
+	assert.Nil(t, quotes["7083"].TotalSupply)
+	assert.Equal(t, "370.638015413", quotes["1027"].Quote["USD"].Price.String())
+}
@@ -0,0 +1,190 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// Option configures a CoinmarketcapClient created by NewClient.
+// Options are applied in the order they are passed.
+type Option func(c *CoinmarketcapClient) error
+
+// NewClient creates a client for the Basic plan talking to the production
+// API with the API key taken from the CMC_PRO_API_KEY environment variable.
+// Use options to override any of these defaults.
+func NewClient(opts ...Option) (*CoinmarketcapClient, error) {
+	c := &CoinmarketcapClient{}
+	if err := c.init(opts...); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// init fills in defaults for everything not yet configured and applies opts.
+func (c *CoinmarketcapClient) init(opts ...Option) error {
+	if c.client == nil {
+		c.client = &http.Client{}
+	}
+	if c.limiter == nil {
+		c.setLimiter(NewLimiter(types.APIPlanLimits[types.Basic]))
+	}
+	if c.baseUrl == "" {
+		c.baseUrl = string(Production)
+	}
+	if c.logger == nil {
+		c.logger = log.StandardLogger()
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return err
+		}
+	}
+
+	// the http client options are applied last, so they also apply to a
+	// client passed with WithHTTPClient, and to a copy, so they never modify
+	// a client shared with others such as http.DefaultClient
+	if len(c.httpClientOptions) > 0 {
+		client := *c.client
+		for _, apply := range c.httpClientOptions {
+			apply(&client)
+		}
+		c.client = &client
+		c.httpClientOptions = nil
+	}
+
+	return nil
+}
+
+// WithPlan sets up rate limiting according to the quotas of the given API plan.
+func WithPlan(plan types.ApiPlan) Option {
+	return func(c *CoinmarketcapClient) error {
+		limits, ok := types.APIPlanLimits[plan]
+		if !ok {
+			return fmt.Errorf("unknown api plan %d", plan)
+		}
+		c.setLimiter(NewLimiter(limits))
+		return nil
+	}
+}
+
+// WithPlanLimits sets up rate limiting for a custom plan, e.g. a negotiated
+// enterprise contract.
+func WithPlanLimits(limits types.PlanLimits) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.setLimiter(NewLimiter(limits))
+		return nil
+	}
+}
+
+// WithLimiter replaces the plan based rate limiter, e.g. with a *rate.Limiter
+// shared by several clients. The credit quotas of a *Limiter are enforced by
+// the client, other limiters disable them.
+func WithLimiter(limiter RateLimiter) Option {
+	return func(c *CoinmarketcapClient) error {
+		if limiter == nil {
+			return errors.New("limiter must not be nil")
+		}
+		c.setLimiter(limiter)
+		return nil
+	}
+}
+
+// setLimiter sets the limiter of the client and the credit quotas enforced
+// along with it.
+func (c *CoinmarketcapClient) setLimiter(limiter RateLimiter) {
+	c.limiter = limiter
+
+	var limits types.PlanLimits
+	if planLimiter, ok := limiter.(*Limiter); ok {
+		limits = planLimiter.Limits()
+	}
+	c.credits.setQuota(limits)
+}
+
+// WithHTTPClient replaces the underlying http.Client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *CoinmarketcapClient) error {
+		if client == nil {
+			return errors.New("http client must not be nil")
+		}
+		c.client = client
+		return nil
+	}
+}
+
+// WithTransport sets the transport of the underlying http.Client, regardless
+// of whether it comes before or after WithHTTPClient.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.httpClientOptions = append(c.httpClientOptions, func(client *http.Client) {
+			client.Transport = transport
+		})
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the underlying http.Client, regardless of
+// whether it comes before or after WithHTTPClient.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.httpClientOptions = append(c.httpClientOptions, func(client *http.Client) {
+			client.Timeout = timeout
+		})
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request instead
+// of DefaultUserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets the logger used by the client. The API key is never logged.
+func WithLogger(logger log.FieldLogger) Option {
+	return func(c *CoinmarketcapClient) error {
+		if logger == nil {
+			return errors.New("logger must not be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithEnvironment points the client at the production or sandbox API.
+func WithEnvironment(env Environment) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.SetEnvironment(env)
+		return nil
+	}
+}
+
+// WithBaseURL points the client at a custom host.
+func WithBaseURL(baseUrl string) Option {
+	return func(c *CoinmarketcapClient) error {
+		return c.SetBaseURL(baseUrl)
+	}
+}
+
+// WithCredentials sets the provider of the API key.
+func WithCredentials(credentials CredentialProvider) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.SetCredentials(credentials)
+		return nil
+	}
+}
+
+// WithApiKey is a shorthand for WithCredentials(StaticApiKey(apiKey)).
+func WithApiKey(apiKey string) Option {
+	return WithCredentials(StaticApiKey(apiKey))
+}
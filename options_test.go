@@ -0,0 +1,113 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewClient_Defaults(t *testing.T) {
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, API_URL, c.baseUrl)
+	assert.Equal(t, types.APIPlanLimits[types.Basic], c.limiter.(*Limiter).Limits())
+	assert.NotNil(t, c.client)
+	assert.NotNil(t, c.logger)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	var userAgent string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		userAgent = r.Header.Get("User-Agent")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"status":{},"data":[]}`)),
+		}, nil
+	})
+
+	logger := log.New()
+	c, err := NewClient(
+		WithPlan(types.Professional),
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithUserAgent("my-service/1.0"),
+		WithLogger(logger),
+		WithEnvironment(Sandbox),
+		WithApiKey("test-key"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, types.APIPlanLimits[types.Professional], c.limiter.(*Limiter).Limits())
+	assert.Equal(t, 5*time.Second, c.client.Timeout)
+	assert.Equal(t, SANDBOX_URL, c.baseUrl)
+	assert.Equal(t, logger, c.logger)
+
+	_, err = c.FiatMap(&types.FiatMapRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-service/1.0", userAgent)
+}
+
+func TestNewClient_HTTPClientOptionsDoNotModifySharedClient(t *testing.T) {
+	shared := &http.Client{}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("unused")
+	})
+
+	for _, opts := range [][]Option{
+		{WithHTTPClient(shared), WithTransport(transport), WithTimeout(5 * time.Second)},
+		{WithTransport(transport), WithTimeout(5 * time.Second), WithHTTPClient(shared)},
+	} {
+		c, err := NewClient(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5*time.Second, c.client.Timeout)
+		assert.NotNil(t, c.client.Transport)
+		assert.Zero(t, shared.Timeout)
+		assert.Nil(t, shared.Transport)
+	}
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	_, err := NewClient(WithBaseURL("not a url"))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithLimiter(nil))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithHTTPClient(nil))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithPlan(types.ApiPlan(42)))
+	assert.Error(t, err)
+}
+
+func TestCoinmarketcapClient_InitKeepsConfiguration(t *testing.T) {
+	c := &CoinmarketcapClient{}
+	c.SetEnvironment(Sandbox)
+	if err := c.Init(types.Standard); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, SANDBOX_URL, c.baseUrl)
+	assert.Equal(t, types.APIPlanLimits[types.Standard], c.limiter.(*Limiter).Limits())
+}
@@ -0,0 +1,178 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// MaxPageSize is the largest limit the paginated endpoints accept.
+const MaxPageSize = 5000
+
+// Iterator walks all items of a paginated endpoint, fetching the next page
+// when the current one is exhausted. Stop calling Next to stop early.
+//
+//	it := client.CryptocurrencyIdMapIterator(ctx, &types.CryptocurrencyMapRequest{})
+//	for it.Next() {
+//		asset := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, start, limit int) ([]T, error)
+	start    int
+	pageSize int
+
+	//Whether the endpoint is called once without paging.
+	unpaged bool
+
+	page  []T
+	index int
+	last  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, start, pageSize int, fetch func(ctx context.Context, start, limit int) ([]T, error)) *Iterator[T] {
+	it := &Iterator[T]{ctx: ctx, fetch: fetch, start: start, pageSize: pageSize, index: -1}
+	if it.start < 1 {
+		it.start = 1
+	}
+	if it.pageSize == 0 {
+		it.pageSize = MaxPageSize
+	}
+	if it.pageSize < 0 || it.pageSize > MaxPageSize {
+		it.err = fmt.Errorf("page size must be in range [1..%d], got %d", MaxPageSize, it.pageSize)
+	}
+
+	return it
+}
+
+// Next advances to the next item, fetching a new page if needed. It returns
+// false when all items were visited or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.page) {
+		return true
+	}
+	if it.last {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = &CanceledError{Err: err}
+		return false
+	}
+
+	page, err := it.fetch(it.ctx, it.start, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = page
+	it.index = 0
+	it.start += len(page)
+	// a short page is the last one
+	it.last = it.unpaged || len(page) < it.pageSize
+
+	return len(page) > 0
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+
+	return items, it.Err()
+}
+
+// requestValue returns a copy of request, the zero value if it is nil.
+func requestValue[Req any](request *Req) Req {
+	var value Req
+	if request != nil {
+		value = *request
+	}
+	return value
+}
+
+// CryptocurrencyIdMapIterator walks all pages of CryptocurrencyIdMap. The
+// request's Limit is used as the page size, MaxPageSize if not set. Symbol
+// lookups are not paginated by the API, they are made in a single call.
+func (c *CoinmarketcapClient) CryptocurrencyIdMapIterator(ctx context.Context, request *types.CryptocurrencyMapRequest) *Iterator[types.Cryptocurrency] {
+	page := requestValue(request)
+	if page.Symbol != "" || len(page.Symbols) > 0 {
+		it := newIterator(ctx, 0, 0, func(ctx context.Context, _, _ int) ([]types.Cryptocurrency, error) {
+			return c.CryptocurrencyIdMapWithContext(ctx, &page)
+		})
+		it.unpaged = true
+		return it
+	}
+
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.Cryptocurrency, error) {
+		page.Start, page.Limit = start, limit
+		return c.CryptocurrencyIdMapWithContext(ctx, &page)
+	})
+}
+
+// CryptocurrencyListingsLatestIterator walks all pages of CryptocurrencyListingsLatest.
+func (c *CoinmarketcapClient) CryptocurrencyListingsLatestIterator(ctx context.Context, request *types.CryptocurrencyListingsLatestRequest) *Iterator[types.CryptocurrencyListing] {
+	page := requestValue(request)
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.CryptocurrencyListing, error) {
+		page.Start, page.Limit = start, limit
+		return c.CryptocurrencyListingsLatestWithContext(ctx, &page)
+	})
+}
+
+// CryptocurrencyListingsHistoricalIterator walks all pages of CryptocurrencyListingsHistorical.
+func (c *CoinmarketcapClient) CryptocurrencyListingsHistoricalIterator(ctx context.Context, request *types.CryptocurrencyListingsHistoricalRequest) *Iterator[types.CryptocurrencyListing] {
+	page := requestValue(request)
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.CryptocurrencyListing, error) {
+		page.Start, page.Limit = start, limit
+		return c.CryptocurrencyListingsHistoricalWithContext(ctx, &page)
+	})
+}
+
+// ExchangeIdMapIterator walks all pages of ExchangeIdMap.
+func (c *CoinmarketcapClient) ExchangeIdMapIterator(ctx context.Context, request *types.ExchangeIdMapRequest) *Iterator[types.Exchange] {
+	page := requestValue(request)
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.Exchange, error) {
+		page.Start, page.Limit = start, limit
+		return c.ExchangeIdMapWithContext(ctx, &page)
+	})
+}
+
+// FiatMapIterator walks all pages of FiatMap.
+func (c *CoinmarketcapClient) FiatMapIterator(ctx context.Context, request *types.FiatMapRequest) *Iterator[types.Fiat] {
+	page := requestValue(request)
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.Fiat, error) {
+		page.Start, page.Limit = start, limit
+		return c.FiatMapWithContext(ctx, &page)
+	})
+}
+
+// PartnersFCASListingsLatestIterator walks all pages of PartnersFCASListingsLatest.
+func (c *CoinmarketcapClient) PartnersFCASListingsLatestIterator(ctx context.Context, request *types.FCASListingsLatestRequest) *Iterator[types.FCASRating] {
+	page := requestValue(request)
+	return newIterator(ctx, page.Start, page.Limit, func(ctx context.Context, start, limit int) ([]types.FCASRating, error) {
+		page.Start, page.Limit = start, limit
+		return c.PartnersFCASListingsLatestWithContext(ctx, &page)
+	})
+}
@@ -0,0 +1,117 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedHandler serves total items with ids 1..total honoring start and limit.
+func pagedHandler(t *testing.T, total int, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var items []string
+		for id := start; id < start+limit && id <= total; id++ {
+			items = append(items, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		w.Write([]byte(`{"status":{},"data":[` + strings.Join(items, ",") + `]}`))
+	}
+}
+
+func TestIterator_WalksAllPages(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, pagedHandler(t, 7, &requests))
+
+	it := c.CryptocurrencyIdMapIterator(context.Background(), &types.CryptocurrencyMapRequest{Limit: 3, ListingStatus: "active"})
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().Id)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, ids)
+	assert.Equal(t, []string{
+		"limit=3&listing_status=active&start=1",
+		"limit=3&listing_status=active&start=4",
+		"limit=3&listing_status=active&start=7",
+	}, requests)
+}
+
+func TestIterator_ExactMultipleOfPageSize(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, pagedHandler(t, 4, &requests))
+
+	fiats, err := c.FiatMapIterator(context.Background(), &types.FiatMapRequest{Limit: 2}).All()
+	assert.NoError(t, err)
+	assert.Len(t, fiats, 4)
+	assert.Len(t, requests, 3)
+}
+
+func TestIterator_EarlyStop(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, pagedHandler(t, 100, &requests))
+
+	it := c.ExchangeIdMapIterator(context.Background(), &types.ExchangeIdMapRequest{Limit: 10})
+	for i := 0; i < 5 && it.Next(); i++ {
+	}
+	assert.Len(t, requests, 1)
+}
+
+func TestIterator_DefaultPageSize(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, pagedHandler(t, 10, &requests))
+
+	listings, err := c.CryptocurrencyListingsLatestIterator(context.Background(), &types.CryptocurrencyListingsLatestRequest{}).All()
+	assert.NoError(t, err)
+	assert.Len(t, listings, 10)
+	assert.Equal(t, []string{"limit=5000&start=1"}, requests)
+}
+
+func TestIterator_InvalidPageSize(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+
+	_, err := c.PartnersFCASListingsLatestIterator(context.Background(), &types.FCASListingsLatestRequest{Limit: 5001}).All()
+	assert.Error(t, err)
+}
+
+func TestIterator_Canceled(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, pagedHandler(t, 100, &requests))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.CryptocurrencyListingsHistoricalIterator(ctx, &types.CryptocurrencyListingsHistoricalRequest{Date: "2019-08-19", Limit: 10})
+	for i := 0; i < 10; i++ {
+		assert.True(t, it.Next())
+	}
+	cancel()
+
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), context.Canceled))
+	assert.Len(t, requests, 1)
+}
+
+func TestIterator_SymbolLookup(t *testing.T) {
+	var requests []string
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		w.Write([]byte(`{"status":{},"data":[{"id":1,"symbol":"BTC"},{"id":1027,"symbol":"ETH"}]}`))
+	})
+
+	assets, err := c.CryptocurrencyIdMapIterator(context.Background(), &types.CryptocurrencyMapRequest{Symbols: []string{"BTC", "ETH"}}).All()
+	assert.NoError(t, err)
+	assert.Len(t, assets, 2)
+	assert.Equal(t, []string{"symbol=BTC%2CETH"}, requests)
+}
@@ -0,0 +1,307 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/google/go-querystring/query"
+	log "github.com/sirupsen/logrus"
+)
+
+// endpoint describes an API operation taking a Req query and returning Data
+// in the standard response envelope.
+type endpoint[Req any, Data any] struct {
+	//Name of the client method, used in errors and credit accounting.
+	name string
+
+	//Path of the operation relative to the base URL.
+	path string
+
+	//How long responses are cached by default, zero disables caching.
+	ttl time.Duration
+
+	//Query parameters holding comma-separated asset lists, for endpoints
+	//whose calls are split into chunks.
+	lists []string
+}
+
+// execute is the single request pipeline every endpoint goes through:
+// it validates the request, builds the HTTP request, serves it from the
+// cache if possible and otherwise passes it through the middleware chain to
+// send, which performs it under the client's limits and retry policy,
+// decodes the response envelope and accounts for the used credits.
+func execute[Req any, Data any](ctx context.Context, c *CoinmarketcapClient, ep endpoint[Req, Data], request *Req) (*Response[Data], error) {
+	values, err := encodeQuery(request)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ep.name, err)
+	}
+
+	return executeQuery(ctx, c, ep, request, values)
+}
+
+// executeQuery is execute with the query parameters of request already
+// encoded into values.
+func executeQuery[Req any, Data any](ctx context.Context, c *CoinmarketcapClient, ep endpoint[Req, Data], request *Req, values url.Values) (*Response[Data], error) {
+	start := time.Now()
+	key := c.cacheKey(ep.name, values.Encode())
+	ttl := c.cacheTTL(ep.name, ep.ttl)
+	mode := cacheModeFrom(ctx)
+	useCache := c.cache != nil && ttl > 0 && mode != CacheBypass
+
+	// the HTTP request is only built once the call is not served from the
+	// cache, so cached results do not depend on the credentials
+	fetch := func(ctx context.Context) (*Response[Data], error) {
+		httpRequest, err := c.newRequest(ctx, ep.name, ep.path, values)
+		if err != nil {
+			return nil, err
+		}
+
+		var response types.Response[Data]
+		call := &Call{Endpoint: ep.name, Request: request, HTTPRequest: httpRequest}
+		err = c.chain(func(ctx context.Context, call *Call) error {
+			return send(ctx, c, call, &response)
+		})(ctx, call)
+		if err != nil {
+			return nil, err
+		}
+
+		if useCache && call.body != nil {
+			now := time.Now()
+			c.cache.Set(key, &CacheEntry{Body: call.body, StoredAt: now, Expires: now.Add(ttl)})
+		}
+
+		result := &Response[Data]{Data: response.Data, Status: response.Status}
+		if call.HTTPResponse != nil {
+			result.HTTPStatus = call.HTTPResponse.StatusCode
+			result.Header = call.HTTPResponse.Header
+		}
+		result.WireBytes, result.DecodedBytes = call.WireBytes, call.DecodedBytes
+		return result, nil
+	}
+
+	if c.flights != nil {
+		uncoalesced := fetch
+		fetch = func(ctx context.Context) (*Response[Data], error) {
+			value, err := c.flights.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+				return uncoalesced(ctx)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return value.(*Response[Data]), nil
+		}
+	}
+
+	// responses are copied before the latency is set since coalesced calls
+	// share them
+	done := func(response Response[Data]) *Response[Data] {
+		response.Latency = time.Since(start)
+		return &response
+	}
+
+	var stale *types.Response[Data]
+	var staleEntry *CacheEntry
+	if useCache && mode == CacheDefault {
+		entry, ok := c.cache.Get(key)
+		var cached types.Response[Data]
+		if ok && json.Unmarshal(entry.Body, &cached) == nil {
+			now := time.Now()
+			switch {
+			case now.Before(entry.Expires):
+				reportCacheResult(ctx, entry, false, nil)
+				return done(cachedResponse(&cached, entry, false)), nil
+			case now.Before(entry.Expires.Add(c.staleWhileRevalidate)):
+				c.revalidate(key, func(ctx context.Context) error {
+					_, err := fetch(ctx)
+					return err
+				})
+				reportCacheResult(ctx, entry, true, nil)
+				return done(cachedResponse(&cached, entry, true)), nil
+			case now.Before(entry.Expires.Add(c.staleIfError)):
+				stale, staleEntry = &cached, entry
+			}
+		}
+	}
+
+	response, err := fetch(ctx)
+	if err != nil {
+		if stale != nil && serveStaleOnError(err) {
+			reportCacheResult(ctx, staleEntry, true, err)
+			return done(cachedResponse(stale, staleEntry, true)), nil
+		}
+		return nil, err
+	}
+
+	return done(*response), nil
+}
+
+// send is the innermost handler of the middleware chain. It performs the
+// HTTP request of call and decodes the response envelope into response.
+func send[Data any](ctx context.Context, c *CoinmarketcapClient, call *Call, response *types.Response[Data]) error {
+	resp, err := c.performHttpRequest(ctx, call.Endpoint, call.HTTPRequest.WithContext(ctx))
+	if err != nil {
+		return callFailed(call, err)
+	}
+	defer resp.Body.Close()
+	call.HTTPResponse = resp
+	defer recordBytes(call)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: reading response: %w", call.Endpoint, contextError(ctx, err))
+	}
+
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return fmt.Errorf("%s: decoding response: %w", call.Endpoint, err)
+	}
+	call.body = body
+
+	call.Status = &response.Status
+	c.recordCredits(call.Endpoint, response.Status.CreditCount)
+
+	return nil
+}
+
+// callFailed fills in the response fields of call from the *APIError err
+// wraps, if any, and returns err.
+func callFailed(call *Call, err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		call.HTTPResponse = apiErr.response
+		call.Status = apiErr.status
+		recordBytes(call)
+	}
+	return err
+}
+
+// newRequest builds the HTTP request of a call to the endpoint at path.
+func (c *CoinmarketcapClient) newRequest(ctx context.Context, name string, path string, values url.Values) (*http.Request, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	err = c.prepareHttpRequest(ctx, httpRequest, values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return httpRequest, nil
+}
+
+// validatable is implemented by all request types of the types package.
+type validatable interface {
+	Validate() error
+}
+
+// encodeQuery validates a request struct and encodes its query parameters.
+// A parameter set through several fields, such as Id and Ids, is sent as one
+// comma-separated list. A nil request is the same as an empty one.
+func encodeQuery[Req any](request *Req) (url.Values, error) {
+	if request == nil {
+		request = new(Req)
+	}
+	if v, ok := interface{}(request).(validatable); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := query.Values(request)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, list := range values {
+		if len(list) > 1 {
+			values.Set(key, strings.Join(list, ","))
+		}
+	}
+
+	return values, nil
+}
+
+func (c *CoinmarketcapClient) prepareHttpRequest(ctx context.Context, httpRequest *http.Request, values url.Values) error {
+	apiKey, err := c.apiKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	userAgent := c.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	httpRequest.Header.Set("Accept", "application/json")
+	httpRequest.Header.Set("Accept-Encoding", acceptEncoding)
+	httpRequest.Header.Set("X-CMC_PRO_API_KEY", apiKey)
+	httpRequest.Header.Set("User-Agent", userAgent)
+	httpRequest.URL.RawQuery = values.Encode()
+
+	return nil
+}
+
+// performHttpRequest sends req, retrying it according to the client's retry
+// policy, and returns the response if its status is 200 OK. Any other status
+// is returned as an *APIError. The body of the response is decoded according
+// to its Content-Encoding.
+func (c *CoinmarketcapClient) performHttpRequest(ctx context.Context, endpoint string, req *http.Request) (*http.Response, error) {
+	if err := c.credits.checkBudget(); err != nil {
+		return nil, err
+	}
+
+	maxAttempts := c.retryPolicy.maxAttempts(req)
+
+	for attempt := 1; ; attempt++ {
+		err := c.limiter.Wait(ctx)
+		if err != nil {
+			return nil, limiterError(ctx, err)
+		}
+
+		c.logger.WithFields(log.Fields{"url": req.URL.String(), "attempt": attempt}).Debug("coinmarketcap: sending request")
+		resp, err := c.client.Do(req.WithContext(ctx))
+
+		var retryAfter time.Duration
+		if err != nil {
+			err = contextError(ctx, err)
+			if _, canceled := err.(*CanceledError); canceled || !c.retryPolicy.RetryTransportErrors || attempt >= maxAttempts {
+				if attempt > 1 {
+					return nil, fmt.Errorf("%s: giving up after %d attempts: %w", endpoint, attempt, err)
+				}
+				return nil, fmt.Errorf("%s: %w", endpoint, err)
+			}
+		} else {
+			if err := decodeResponse(resp); err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("%s: %w", endpoint, err)
+			}
+			if resp.StatusCode == http.StatusOK {
+				return resp, nil
+			}
+
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			apiErr := newAPIError(endpoint, resp)
+			apiErr.Attempts = attempt
+			c.recordCredits(endpoint, apiErr.CreditCount)
+			if attempt >= maxAttempts || !c.retryPolicy.retryable(apiErr) || c.retryPolicy.exceedsMaxBackoff(retryAfter) {
+				return nil, apiErr
+			}
+			err = apiErr
+		}
+
+		backoff := c.retryPolicy.backoff(attempt, retryAfter)
+		c.logger.WithFields(log.Fields{"endpoint": endpoint, "attempt": attempt, "backoff": backoff}).Warnf("coinmarketcap: retrying: %s", err)
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+	}
+}
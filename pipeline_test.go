@@ -0,0 +1,132 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type countingLimiter struct {
+	waits int
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return nil
+}
+
+func TestExecute_ClosesBodyAndUsesLimiter(t *testing.T) {
+	var bodies []*trackingBody
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := &trackingBody{Reader: strings.NewReader(`{"status":{"credit_count":1},"data":{"BTC":{"id":1,"symbol":"BTC"}}}`)}
+		bodies = append(bodies, body)
+		return &http.Response{StatusCode: http.StatusOK, Body: body, Header: http.Header{}}, nil
+	})
+
+	limiter := &countingLimiter{}
+	c, err := NewClient(WithTransport(transport), WithLimiter(limiter), WithApiKey("test-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := c.CryptocurrencyInfo(&types.CryptocurrencyInfoRequest{Symbol: "BTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, info["BTC"].Id)
+	assert.Equal(t, 1, limiter.waits)
+	assert.Equal(t, 1, c.CreditUsage().ByEndpoint["CryptocurrencyInfo"])
+	if assert.Len(t, bodies, 1) {
+		assert.True(t, bodies[0].closed, "response body was not closed")
+	}
+}
+
+func TestExecute_DecodeError(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":`))
+	})
+
+	_, err := c.GlobalMetricsQuotesLatest(&types.GlobalMetricsQuotesLatestRequest{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "GlobalMetricsQuotesLatest: decoding response")
+	}
+}
+
+func TestExecute_GlobalMetricsQuotesHistorical(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/global-metrics/quotes/historical", r.URL.Path)
+		w.Write([]byte(`{"status":{},"data":{"quotes":[{"btc_dominance":63.2},{"btc_dominance":63.5}]}}`))
+	})
+
+	quotes, err := c.GlobalMetricsQuotesHistorical(&types.GlobalMetricsQuotesHistoricalRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, 63.5, quotes[1].BTCDominance)
+}
+
+func TestEncodeQuery_TypedSlices(t *testing.T) {
+	values, err := encodeQuery(&types.CryptocurrencyQuotesLatestRequest{
+		Ids:            []int{1, 1027},
+		ConvertSymbols: []string{"USD", "EUR"},
+		ConvertId:      "2781",
+		ConvertIds:     []int{2790},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "1,1027", values.Get("id"))
+	assert.Equal(t, "USD,EUR", values.Get("convert"))
+	assert.Equal(t, []string{"2781,2790"}, values["convert_id"])
+	assert.Empty(t, values.Get("symbol"))
+
+	values, err = encodeQuery(&types.CryptocurrencyMapRequest{
+		ListingStatuses: []types.ListingStatus{types.ListingActive, types.ListingUntracked},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "active,untracked", values.Get("listing_status"))
+}
+
+func TestCoinmarketcapClient_NilRequests(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/global-metrics") {
+			w.Write([]byte(`{"status":{},"data":{}}`))
+			return
+		}
+		w.Write([]byte(`{"status":{},"data":[]}`))
+	})
+
+	_, err := c.FiatMap(nil)
+	assert.NoError(t, err)
+	_, err = c.GlobalMetricsQuotesLatest(nil)
+	assert.NoError(t, err)
+
+	fiats, err := c.FiatMapIterator(context.Background(), nil).All()
+	assert.NoError(t, err)
+	assert.Empty(t, fiats)
+
+	_, err = c.CryptocurrencyQuotesLatest(nil)
+	assert.True(t, errors.Is(err, ErrInvalidParameter), "unexpected error: %v", err)
+}
@@ -0,0 +1,142 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// Response is the decoded data of a call together with its metadata.
+// Like the plain methods, the WithResponse methods of multi-asset endpoints
+// return the merged successful chunks alongside a *ChunkError.
+type Response[T any] struct {
+	Data T
+
+	//The status object of the response envelope. For calls split into
+	//chunks, CreditCount and Elapsed are summed up over all chunks.
+	Status types.ResponseStatus
+
+	//HTTP status code of the response. Responses served from the cache report
+	//the status they were stored with, 200 OK.
+	HTTPStatus int
+
+	//Headers of the response, nil for responses served from the cache.
+	Header http.Header
+
+	//Wall-clock time the call took, including rate limiting and retries.
+	Latency time.Duration
+
+	//Whether the response was served from the cache instead of the API.
+	FromCache bool
+
+	//Whether the cached response had already expired, see WithStaleWhileRevalidate and WithStaleIfError.
+	Stale bool
+
+	//Time since a response served from the cache was stored. For calls split
+	//into chunks it is the age of the oldest chunk.
+	Age time.Duration
+
+	//Size of the response body as received and after decoding its
+	//Content-Encoding, zero for responses served from the cache. For calls
+	//split into chunks they are summed up over all chunks.
+	WireBytes    int64
+	DecodedBytes int64
+}
+
+func cachedResponse[T any](cached *types.Response[T], entry *CacheEntry, stale bool) Response[T] {
+	return Response[T]{
+		Data:       cached.Data,
+		Status:     cached.Status,
+		HTTPStatus: http.StatusOK,
+		FromCache:  true,
+		Stale:      stale,
+		Age:        time.Since(entry.StoredAt),
+	}
+}
+
+// ------ Cryptocurrency ------ //
+
+// CryptocurrencyIdMapWithResponse is the same as CryptocurrencyIdMapWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyIdMapWithResponse(ctx context.Context, request *types.CryptocurrencyMapRequest) (*Response[[]types.Cryptocurrency], error) {
+	return execute(ctx, c, cryptocurrencyIdMapEndpoint, request)
+}
+
+// CryptocurrencyInfoWithResponse is the same as CryptocurrencyInfoWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyInfoWithResponse(ctx context.Context, request *types.CryptocurrencyInfoRequest) (*Response[map[string]*types.CryptocurrencyInfo], error) {
+	return executeChunked(ctx, c, cryptocurrencyInfoEndpoint, request)
+}
+
+// CryptocurrencyListingsHistoricalWithResponse is the same as CryptocurrencyListingsHistoricalWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyListingsHistoricalWithResponse(ctx context.Context, request *types.CryptocurrencyListingsHistoricalRequest) (*Response[[]types.CryptocurrencyListing], error) {
+	return execute(ctx, c, cryptocurrencyListingsHistoricalEndpoint, request)
+}
+
+// CryptocurrencyListingsLatestWithResponse is the same as CryptocurrencyListingsLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyListingsLatestWithResponse(ctx context.Context, request *types.CryptocurrencyListingsLatestRequest) (*Response[[]types.CryptocurrencyListing], error) {
+	return execute(ctx, c, cryptocurrencyListingsLatestEndpoint, request)
+}
+
+// CryptocurrencyOHLCVHistoricalWithResponse is the same as CryptocurrencyOHLCVHistoricalWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVHistoricalWithResponse(ctx context.Context, request *types.CryptocurrencyOHLCVHistoricalRequest) (*Response[map[string]*types.OHLCVHistoricalResult], error) {
+	return execute(ctx, c, cryptocurrencyOHLCVHistoricalEndpoint, request)
+}
+
+// CryptocurrencyOHLCVLatestWithResponse is the same as CryptocurrencyOHLCVLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyOHLCVLatestWithResponse(ctx context.Context, request *types.CryptocurrencyOHLCVLatestRequest) (*Response[map[string]*types.CryptocurrencyOHLCV], error) {
+	return executeChunked(ctx, c, cryptocurrencyOHLCVLatestEndpoint, request)
+}
+
+// CryptocurrencyQuotesLatestWithResponse is the same as CryptocurrencyQuotesLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyQuotesLatestWithResponse(ctx context.Context, request *types.CryptocurrencyQuotesLatestRequest) (*Response[map[string]types.CryptocurrencyQuote], error) {
+	return executeChunked(ctx, c, cryptocurrencyQuotesLatestEndpoint, request)
+}
+
+// CryptocurrencyPricePerformanceStatsWithResponse is the same as CryptocurrencyPricePerformanceStatsWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) CryptocurrencyPricePerformanceStatsWithResponse(ctx context.Context, request *types.CryptocurrencyPricePerformanceStatsRequest) (*Response[map[string]*types.PricePerformanceStats], error) {
+	return execute(ctx, c, cryptocurrencyPricePerformanceStatsEndpoint, request)
+}
+
+// ------ Fiat ------ //
+
+// FiatMapWithResponse is the same as FiatMapWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) FiatMapWithResponse(ctx context.Context, request *types.FiatMapRequest) (*Response[[]types.Fiat], error) {
+	return execute(ctx, c, fiatMapEndpoint, request)
+}
+
+// ------ Exchange ------ //
+
+// ExchangeInfoWithResponse is the same as ExchangeInfoWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) ExchangeInfoWithResponse(ctx context.Context, request *types.ExchangeInfoRequest) (*Response[map[string]*types.ExchangeInfo], error) {
+	return executeChunked(ctx, c, exchangeInfoEndpoint, request)
+}
+
+// ExchangeIdMapWithResponse is the same as ExchangeIdMapWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) ExchangeIdMapWithResponse(ctx context.Context, request *types.ExchangeIdMapRequest) (*Response[[]types.Exchange], error) {
+	return execute(ctx, c, exchangeIdMapEndpoint, request)
+}
+
+// ------ Global-Metrics ------ //
+
+// GlobalMetricsQuotesLatestWithResponse is the same as GlobalMetricsQuotesLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) GlobalMetricsQuotesLatestWithResponse(ctx context.Context, request *types.GlobalMetricsQuotesLatestRequest) (*Response[types.GlobalMetricsQuotesLatest], error) {
+	return execute(ctx, c, globalMetricsQuotesLatestEndpoint, request)
+}
+
+// GlobalMetricsQuotesHistoricalWithResponse is the same as GlobalMetricsQuotesHistoricalWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) GlobalMetricsQuotesHistoricalWithResponse(ctx context.Context, request *types.GlobalMetricsQuotesHistoricalRequest) (*Response[types.GlobalMetricsQuotesHistorical], error) {
+	return execute(ctx, c, globalMetricsQuotesHistoricalEndpoint, request)
+}
+
+// ------ Partners ------ //
+
+// PartnersFCASListingsLatestWithResponse is the same as PartnersFCASListingsLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) PartnersFCASListingsLatestWithResponse(ctx context.Context, request *types.FCASListingsLatestRequest) (*Response[[]types.FCASRating], error) {
+	return execute(ctx, c, partnersFCASListingsLatestEndpoint, request)
+}
+
+// PartnersFCASQuotesLatestWithResponse is the same as PartnersFCASQuotesLatestWithContext, returning the data together with the response metadata.
+func (c *CoinmarketcapClient) PartnersFCASQuotesLatestWithResponse(ctx context.Context, request *types.FCASQuotesLatestRequest) (*Response[map[string]*types.FCASRating], error) {
+	return execute(ctx, c, partnersFCASQuotesLatestEndpoint, request)
+}
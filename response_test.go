@@ -0,0 +1,109 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinmarketcapClient_WithResponse(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-Id", "abc")
+		w.Write([]byte(`{"status":{"timestamp":"2020-08-01T12:00:00.000Z","elapsed":7,"credit_count":1},"data":[{"id":2781,"symbol":"USD"}]}`))
+	})
+	WithCache(NewMemoryCache(10))(c)
+
+	resp, err := c.FiatMapWithResponse(context.Background(), &types.FiatMapRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "USD", resp.Data[0].Symbol)
+	assert.Equal(t, 7, resp.Status.Elapsed)
+	assert.Equal(t, 1, resp.Status.CreditCount)
+	assert.Equal(t, 2020, resp.Status.Timestamp.Year())
+	assert.Equal(t, http.StatusOK, resp.HTTPStatus)
+	assert.Equal(t, "abc", resp.Header.Get("X-Request-Id"))
+	assert.True(t, resp.Latency > 0)
+	assert.False(t, resp.FromCache)
+
+	resp, err = c.FiatMapWithResponse(context.Background(), &types.FiatMapRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, calls)
+	assert.True(t, resp.FromCache)
+	assert.False(t, resp.Stale)
+	assert.Equal(t, "USD", resp.Data[0].Symbol)
+	assert.Equal(t, 7, resp.Status.Elapsed)
+}
+
+func TestCoinmarketcapClient_WithResponseStale(t *testing.T) {
+	failing := false
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":{},"data":{"btc_dominance":60}}`))
+	})
+	cache := NewMemoryCache(10)
+	WithCache(cache)(c)
+	WithStaleIfError(10 * time.Minute)(c)
+
+	resp, err := c.GlobalMetricsQuotesLatestWithResponse(context.Background(), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.NoError(t, err)
+	assert.Zero(t, resp.Age)
+
+	failing = true
+	expireCacheEntry(t, cache, c.cacheKey("GlobalMetricsQuotesLatest", ""), 2*time.Minute)
+
+	resp, err = c.GlobalMetricsQuotesLatestWithResponse(context.Background(), &types.GlobalMetricsQuotesLatestRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 60.0, resp.Data.BTCDominance)
+	assert.True(t, resp.FromCache)
+	assert.True(t, resp.Stale)
+	assert.True(t, resp.Age >= 2*time.Minute, "unexpected age %s", resp.Age)
+}
+
+func TestCoinmarketcapClient_WithResponseMergesChunks(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var quotes []string
+		for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+			quotes = append(quotes, fmt.Sprintf(`"%s":{"id":%s}`, id, id))
+		}
+		w.Write([]byte(`{"status":{"credit_count":1,"elapsed":2},"data":{` + strings.Join(quotes, ",") + `}}`))
+	})
+	WithChunkSize(2)(c)
+
+	resp, err := c.CryptocurrencyQuotesLatestWithResponse(context.Background(), &types.CryptocurrencyQuotesLatestRequest{Ids: []int{1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Data, 3)
+	assert.Equal(t, 2, resp.Status.CreditCount)
+	assert.Equal(t, 4, resp.Status.Elapsed)
+	assert.Equal(t, http.StatusOK, resp.HTTPStatus)
+	assert.False(t, resp.FromCache)
+}
+
+func TestCoinmarketcapClient_WithResponseError(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":{"error_code":1002,"error_message":"API key missing."}}`))
+	})
+
+	resp, err := c.GlobalMetricsQuotesLatestWithResponse(context.Background(), &types.GlobalMetricsQuotesLatestRequest{})
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
@@ -0,0 +1,155 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed calls are retried. Only idempotent requests
+// (GET and HEAD, which is every endpoint of the API) are ever retried.
+type RetryPolicy struct {
+	//Total number of attempts including the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	//Delay before the first retry. It grows by Multiplier on every further retry.
+	InitialBackoff time.Duration
+
+	//Upper bound of the backoff. A call is given up with its error when a
+	//Retry-After header asks for a longer wait.
+	MaxBackoff time.Duration
+
+	//Growth factor of the backoff between consecutive retries.
+	Multiplier float64
+
+	//Fraction in [0..1] of the backoff that is randomized to spread out retries.
+	Jitter float64
+
+	//HTTP status codes that are retried when the response carries no API specific error code.
+	RetryableStatusCodes []int
+
+	//API error codes (error_code of the response status) that are retried.
+	RetryableErrorCodes []int
+
+	//Whether transport errors such as connection resets are retried.
+	RetryTransportErrors bool
+}
+
+// DefaultRetryPolicy retries rate limited and temporarily failing calls up to
+// three times. Daily and monthly limits are not retried since waiting a few
+// seconds does not lift them.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableErrorCodes: []int{
+			ErrorCodeMinuteRateLimitReached,
+			ErrorCodeIpRateLimitReached,
+		},
+		RetryTransportErrors: true,
+	}
+}
+
+// WithRetryPolicy enables retries of failed calls. Without it every call is
+// attempted exactly once.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *CoinmarketcapClient) error {
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+func (p *RetryPolicy) maxAttempts(req *http.Request) int {
+	if p.MaxAttempts < 2 || req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(apiErr *APIError) bool {
+	// API specific codes take precedence over the HTTP status, so a 429
+	// caused by an exhausted daily quota is not retried.
+	if apiErr.ErrorCode != apiErr.StatusCode {
+		for _, code := range p.RetryableErrorCodes {
+			if code == apiErr.ErrorCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if code == apiErr.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+// A Retry-After longer than MaxBackoff is not capped here since such calls
+// are given up instead, see exceedsMaxBackoff.
+func (p *RetryPolicy) backoff(retry int, retryAfter time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * rand.Float64()
+	}
+
+	if retryAfter > time.Duration(delay) {
+		return retryAfter
+	}
+	return time.Duration(delay)
+}
+
+// exceedsMaxBackoff reports whether the server asked for a wait longer than
+// the policy allows.
+func (p *RetryPolicy) exceedsMaxBackoff(retryAfter time.Duration) bool {
+	return p.MaxBackoff > 0 && retryAfter > p.MaxBackoff
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return &CanceledError{Err: ctx.Err()}
+	case <-timer.C:
+		return nil
+	}
+}
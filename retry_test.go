@@ -0,0 +1,189 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+// newTimeoutTestServer is newTestServer for a client with the given
+// http.Client timeout. slow reports whether a call should outlast it.
+func newTimeoutTestServer(t *testing.T, timeout time.Duration, slow func() bool, body string, opts ...Option) (*CoinmarketcapClient, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if slow() {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(append([]Option{
+		WithLimiter(rate.NewLimiter(rate.Inf, 1)),
+		WithApiKey("test-key"),
+		WithBaseURL(server.URL),
+		WithTimeout(timeout),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, &calls
+}
+
+func TestCoinmarketcapClient_RetriesClientTimeouts(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.RetryTransportErrors = true
+	c, calls := newTimeoutTestServer(t, 20*time.Millisecond, func() bool { return true }, "", WithRetryPolicy(policy))
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCanceled), "client timeout reported as cancellation: %v", err)
+	assert.Contains(t, err.Error(), "FiatMap: giving up after 4 attempts")
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func TestCoinmarketcapClient_TransportErrorWithoutRetries(t *testing.T) {
+	c, calls := newTimeoutTestServer(t, 20*time.Millisecond, func() bool { return true }, "")
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "FiatMap: "), "missing endpoint prefix: %v", err)
+	}
+	assert.False(t, errors.Is(err, ErrCanceled))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCoinmarketcapClient_RetriesTransientFailures(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status":{"error_code":1008,"error_message":"rate limit"}}`))
+		default:
+			w.Write([]byte(`{"status":{"error_code":0},"data":[{"id":2781,"symbol":"USD"}]}`))
+		}
+	})
+	WithRetryPolicy(fastRetryPolicy())(c)
+
+	fiats, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, fiats, 1)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCoinmarketcapClient_RetryGivesUp(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	WithRetryPolicy(fastRetryPolicy())(c)
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 4, apiErr.Attempts)
+		assert.Contains(t, apiErr.Error(), "after 4 attempts")
+	}
+	assert.Equal(t, 4, calls)
+}
+
+func TestCoinmarketcapClient_DoesNotRetryQuotaErrors(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":{"error_code":1009,"error_message":"daily limit"}}`))
+	})
+	WithRetryPolicy(fastRetryPolicy())(c)
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCoinmarketcapClient_GivesUpOnLongRetryAfter(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":{"error_code":1008,"error_message":"minute limit"}}`))
+	})
+	WithRetryPolicy(fastRetryPolicy())(c)
+
+	start := time.Now()
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.True(t, errors.Is(err, ErrRateLimited), "unexpected error: %v", err)
+	assert.Equal(t, 1, calls)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestCoinmarketcapClient_NoRetriesByDefault(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.FiatMap(&types.FiatMapRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+
+	assert.Equal(t, time.Second, policy.backoff(1, 0))
+	assert.Equal(t, 2*time.Second, policy.backoff(2, 0))
+	assert.Equal(t, 5*time.Second, policy.backoff(4, 0))
+	assert.Equal(t, 3*time.Second, policy.backoff(1, 3*time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.backoff(1, 0)
+		assert.True(t, backoff > 500*time.Millisecond && backoff <= time.Second, "backoff out of range: %s", backoff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, time.Minute, parseRetryAfter("Sat, 01 Aug 2020 12:01:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestRetryPolicy_OnlyIdempotentMethods(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	get, _ := http.NewRequest(http.MethodGet, API_URL, nil)
+	post, _ := http.NewRequest(http.MethodPost, API_URL, nil)
+
+	assert.Equal(t, 4, policy.maxAttempts(get))
+	assert.Equal(t, 1, policy.maxAttempts(post))
+}
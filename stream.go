@@ -0,0 +1,153 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/drankou/coinmarketcap-go/types"
+)
+
+// stream is execute for list endpoints that decodes the elements of the data
+// array one at a time and passes them to fn instead of collecting them, so
+// the response body is never held in memory as a whole. Streamed calls are
+// neither cached nor coalesced for the same reason. An error returned by fn
+// stops the call and is returned as is.
+func stream[Req any, T any](ctx context.Context, c *CoinmarketcapClient, ep endpoint[Req, []T], request *Req, fn func(T) error) error {
+	values, err := encodeQuery(request)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ep.name, err)
+	}
+
+	httpRequest, err := c.newRequest(ctx, ep.name, ep.path, values)
+	if err != nil {
+		return err
+	}
+
+	call := &Call{Endpoint: ep.name, Request: request, HTTPRequest: httpRequest}
+	return c.chain(func(ctx context.Context, call *Call) error {
+		return sendStream(ctx, c, call, fn)
+	})(ctx, call)
+}
+
+// sendStream is send for streamed calls.
+func sendStream[T any](ctx context.Context, c *CoinmarketcapClient, call *Call, fn func(T) error) error {
+	resp, err := c.performHttpRequest(ctx, call.Endpoint, call.HTTPRequest.WithContext(ctx))
+	if err != nil {
+		return callFailed(call, err)
+	}
+	defer resp.Body.Close()
+	call.HTTPResponse = resp
+	defer recordBytes(call)
+
+	// the status object usually precedes the data, so the credits of calls
+	// stopped by fn are accounted for as well
+	var status *types.ResponseStatus
+	defer func() {
+		if status != nil {
+			call.Status = status
+			c.recordCredits(call.Endpoint, status.CreditCount)
+		}
+	}()
+
+	decodeError := func(err error) error {
+		return fmt.Errorf("%s: decoding response: %w", call.Endpoint, contextError(ctx, err))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return decodeError(err)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return decodeError(err)
+		}
+
+		switch token {
+		case "status":
+			status = new(types.ResponseStatus)
+			if err := decoder.Decode(status); err != nil {
+				return decodeError(err)
+			}
+		case "data":
+			token, err := decoder.Token()
+			if err != nil {
+				return decodeError(err)
+			}
+			if token == nil {
+				continue
+			}
+			if token != json.Delim('[') {
+				return decodeError(fmt.Errorf("expected data array, got %v", token))
+			}
+
+			for decoder.More() {
+				var item T
+				if err := decoder.Decode(&item); err != nil {
+					return decodeError(err)
+				}
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return decodeError(err)
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return decodeError(err)
+			}
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// expectDelim reads the next token and checks that it is delim.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+// CryptocurrencyIdMapStream is the same as CryptocurrencyIdMapWithContext, but
+// passes the cryptocurrencies to fn one at a time while the response is
+// decoded instead of returning them all at once.
+func (c *CoinmarketcapClient) CryptocurrencyIdMapStream(ctx context.Context, request *types.CryptocurrencyMapRequest, fn func(types.Cryptocurrency) error) error {
+	return stream(ctx, c, cryptocurrencyIdMapEndpoint, request, fn)
+}
+
+// CryptocurrencyListingsLatestStream is the streaming variant of CryptocurrencyListingsLatestWithContext.
+func (c *CoinmarketcapClient) CryptocurrencyListingsLatestStream(ctx context.Context, request *types.CryptocurrencyListingsLatestRequest, fn func(types.CryptocurrencyListing) error) error {
+	return stream(ctx, c, cryptocurrencyListingsLatestEndpoint, request, fn)
+}
+
+// CryptocurrencyListingsHistoricalStream is the streaming variant of CryptocurrencyListingsHistoricalWithContext.
+func (c *CoinmarketcapClient) CryptocurrencyListingsHistoricalStream(ctx context.Context, request *types.CryptocurrencyListingsHistoricalRequest, fn func(types.CryptocurrencyListing) error) error {
+	return stream(ctx, c, cryptocurrencyListingsHistoricalEndpoint, request, fn)
+}
+
+// ExchangeIdMapStream is the streaming variant of ExchangeIdMapWithContext.
+func (c *CoinmarketcapClient) ExchangeIdMapStream(ctx context.Context, request *types.ExchangeIdMapRequest, fn func(types.Exchange) error) error {
+	return stream(ctx, c, exchangeIdMapEndpoint, request, fn)
+}
+
+// FiatMapStream is the streaming variant of FiatMapWithContext.
+func (c *CoinmarketcapClient) FiatMapStream(ctx context.Context, request *types.FiatMapRequest, fn func(types.Fiat) error) error {
+	return stream(ctx, c, fiatMapEndpoint, request, fn)
+}
+
+// PartnersFCASListingsLatestStream is the streaming variant of PartnersFCASListingsLatestWithContext.
+func (c *CoinmarketcapClient) PartnersFCASListingsLatestStream(ctx context.Context, request *types.FCASListingsLatestRequest, fn func(types.FCASRating) error) error {
+	return stream(ctx, c, partnersFCASListingsLatestEndpoint, request, fn)
+}
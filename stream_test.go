@@ -0,0 +1,129 @@
+package coinmarketcap_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// listingsPayload returns a listings response with n elements.
+func listingsPayload(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"status":{"credit_count":25,"elapsed":31},"data":[`)
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":%d,"name":"Asset %d","symbol":"A%d","slug":"asset-%d","cmc_rank":%d,"num_market_pairs":12,`+
+			`"circulating_supply":18451618,"total_supply":18451618,"max_supply":null,"last_updated":"2020-08-03T09:11:31.000Z",`+
+			`"date_added":"2013-04-28T00:00:00.000Z","tags":["mineable"],"platform":null,"quote":{"USD":{"price":11124.5873829,`+
+			`"volume_24h":28342312415.4306,"percent_change_1h":-0.257341,"percent_change_24h":-6.23551,"percent_change_7d":1.11408,`+
+			`"market_cap":205264094577.9327,"last_updated":"2020-08-03T09:11:31.000Z"}}}`, i, i, i, i, i)
+	}
+	b.WriteString(`]}`)
+	return []byte(b.String())
+}
+
+func TestCoinmarketcapClient_CryptocurrencyListingsLatestStream(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(listingsPayload(50))
+	})
+
+	var ids []int
+	err := c.CryptocurrencyListingsLatestStream(context.Background(), &types.CryptocurrencyListingsLatestRequest{}, func(listing types.CryptocurrencyListing) error {
+		ids = append(ids, listing.Id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 50)
+	assert.Equal(t, 50, ids[49])
+	assert.Equal(t, 25, c.CreditUsage().Total)
+}
+
+func TestCoinmarketcapClient_StreamStoppedByCallback(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(listingsPayload(50))
+	})
+
+	stop := errors.New("stop")
+	count := 0
+	err := c.CryptocurrencyListingsLatestStream(context.Background(), &types.CryptocurrencyListingsLatestRequest{}, func(listing types.CryptocurrencyListing) error {
+		count++
+		if count == 3 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 25, c.CreditUsage().Total)
+}
+
+func TestCoinmarketcapClient_StreamErrors(t *testing.T) {
+	body := `{"status":{},"data":[{"id":1},{"id":`
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/fiat/map" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":{"error_code":1002,"error_message":"API key missing."}}`))
+			return
+		}
+		w.Write([]byte(body))
+	})
+
+	count := 0
+	err := c.CryptocurrencyIdMapStream(context.Background(), &types.CryptocurrencyMapRequest{}, func(types.Cryptocurrency) error {
+		count++
+		return nil
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "CryptocurrencyIdMap: decoding response")
+	}
+	assert.Equal(t, 1, count)
+
+	err = c.FiatMapStream(context.Background(), &types.FiatMapRequest{}, func(types.Fiat) error { return nil })
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
+
+func BenchmarkCryptocurrencyListingsLatest(b *testing.B) {
+	payload := listingsPayload(5000)
+	c, _ := newTestServer(b, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	})
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		listings, err := c.CryptocurrencyListingsLatest(&types.CryptocurrencyListingsLatestRequest{Limit: 5000})
+		if err != nil || len(listings) != 5000 {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCryptocurrencyListingsLatestStream(b *testing.B) {
+	payload := listingsPayload(5000)
+	c, _ := newTestServer(b, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	})
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+		err := c.CryptocurrencyListingsLatestStream(context.Background(), &types.CryptocurrencyListingsLatestRequest{Limit: 5000}, func(types.CryptocurrencyListing) error {
+			count++
+			return nil
+		})
+		if err != nil || count != 5000 {
+			b.Fatal(err)
+		}
+	}
+}
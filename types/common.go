@@ -4,8 +4,6 @@ import (
 	"time"
 )
 
-
-
 type Platform struct {
 	//The unique CoinMarketCap ID for the parent platform cryptocurrency.
 	Id int `json:"id"`
@@ -23,6 +21,31 @@ type Platform struct {
 	TokenAddress string `json:"token_address"`
 }
 
+// Value returns the value of an optional field and whether it is set. Fields
+// the API may return as null, such as MaxSupply or Platform, are pointers that
+// are nil in that case.
+func Value[T any](field *T) (T, bool) {
+	if field == nil {
+		var zero T
+		return zero, false
+	}
+	return *field, true
+}
+
+// ValueOr returns the value of an optional field, or fallback if it is null.
+func ValueOr[T any](field *T, fallback T) T {
+	if field == nil {
+		return fallback
+	}
+	return *field
+}
+
+// Response is the envelope every endpoint wraps its data in.
+type Response[T any] struct {
+	Data   T              `json:"data"`
+	Status ResponseStatus `json:"status"`
+}
+
 type ResponseStatus struct {
 	//Current timestamp (ISO 8601) on the server.
 	Timestamp *time.Time `json:"timestamp"`
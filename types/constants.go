@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"golang.org/x/time/rate"
 	"time"
 )
@@ -19,6 +20,14 @@ const (
 	Token CryptocurrencyCategory = "token"
 )
 
+type ListingStatus string
+
+const (
+	ListingActive    ListingStatus = "active"
+	ListingInActive  ListingStatus = "inactive"
+	ListingUntracked ListingStatus = "untracked"
+)
+
 type ExchangeStatus string
 
 const (
@@ -27,6 +36,166 @@ const (
 	ExchangeUntracked ExchangeStatus = "untracked"
 )
 
+// SortField is a field a list of assets can be sorted by. Every endpoint
+// supports a subset of them, see the SortFields variables.
+type SortField string
+
+const (
+	SortId                           SortField = "id"
+	SortCmcRank                      SortField = "cmc_rank"
+	SortName                         SortField = "name"
+	SortSymbol                       SortField = "symbol"
+	SortDateAdded                    SortField = "date_added"
+	SortMarketCap                    SortField = "market_cap"
+	SortMarketCapStrict              SortField = "market_cap_strict"
+	SortPrice                        SortField = "price"
+	SortCirculatingSupply            SortField = "circulating_supply"
+	SortTotalSupply                  SortField = "total_supply"
+	SortMaxSupply                    SortField = "max_supply"
+	SortNumMarketPairs               SortField = "num_market_pairs"
+	SortVolume24H                    SortField = "volume_24h"
+	SortVolume7D                     SortField = "volume_7d"
+	SortVolume30D                    SortField = "volume_30d"
+	SortPercentChange1H              SortField = "percent_change_1h"
+	SortPercentChange24H             SortField = "percent_change_24h"
+	SortPercentChange7D              SortField = "percent_change_7d"
+	SortMarketCapByTotalSupplyStrict SortField = "market_cap_by_total_supply_strict"
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// AuxField is a supplemental data field an endpoint returns only on request.
+// Every endpoint supports a subset of them, see the AuxFields variables.
+type AuxField string
+
+const (
+	AuxUrls                      AuxField = "urls"
+	AuxLogo                      AuxField = "logo"
+	AuxDescription               AuxField = "description"
+	AuxTags                      AuxField = "tags"
+	AuxPlatform                  AuxField = "platform"
+	AuxDateAdded                 AuxField = "date_added"
+	AuxDateLaunched              AuxField = "date_launched"
+	AuxNotice                    AuxField = "notice"
+	AuxStatus                    AuxField = "status"
+	AuxFirstHistoricalData       AuxField = "first_historical_data"
+	AuxLastHistoricalData        AuxField = "last_historical_data"
+	AuxIsActive                  AuxField = "is_active"
+	AuxIsFiat                    AuxField = "is_fiat"
+	AuxNumMarketPairs            AuxField = "num_market_pairs"
+	AuxCmcRank                   AuxField = "cmc_rank"
+	AuxMaxSupply                 AuxField = "max_supply"
+	AuxCirculatingSupply         AuxField = "circulating_supply"
+	AuxTotalSupply               AuxField = "total_supply"
+	AuxMarketCapByTotalSupply    AuxField = "market_cap_by_total_supply"
+	AuxVolume24HReported         AuxField = "volume_24h_reported"
+	AuxVolume7D                  AuxField = "volume_7d"
+	AuxVolume7DReported          AuxField = "volume_7d_reported"
+	AuxVolume30D                 AuxField = "volume_30d"
+	AuxVolume30DReported         AuxField = "volume_30d_reported"
+	AuxIsMarketCapIncludedInCalc AuxField = "is_market_cap_included_in_calc"
+	AuxBTCDominance              AuxField = "btc_dominance"
+	AuxActiveCryptocurrencies    AuxField = "active_cryptocurrencies"
+	AuxActiveExchanges           AuxField = "active_exchanges"
+	AuxActiveMarketPairs         AuxField = "active_market_pairs"
+	AuxTotalVolume24H            AuxField = "total_volume_24h"
+	AuxTotalVolume24HReported    AuxField = "total_volume_24h_reported"
+	AuxAltcoinMarketCap          AuxField = "altcoin_market_cap"
+	AuxAltcoinVolume24H          AuxField = "altcoin_volume_24h"
+	AuxAltcoinVolume24HReported  AuxField = "altcoin_volume_24h_reported"
+	AuxSearchInterval            AuxField = "search_interval"
+	AuxPointChange24H            AuxField = "point_change_24h"
+	AuxPercentChange24H          AuxField = "percent_change_24h"
+)
+
+// Interval is the sampling interval of historical time series.
+type Interval string
+
+const (
+	IntervalHourly  Interval = "hourly"
+	IntervalDaily   Interval = "daily"
+	IntervalWeekly  Interval = "weekly"
+	IntervalMonthly Interval = "monthly"
+	IntervalYearly  Interval = "yearly"
+	Interval5m      Interval = "5m"
+	Interval10m     Interval = "10m"
+	Interval15m     Interval = "15m"
+	Interval30m     Interval = "30m"
+	Interval45m     Interval = "45m"
+	Interval1h      Interval = "1h"
+	Interval2h      Interval = "2h"
+	Interval3h      Interval = "3h"
+	Interval4h      Interval = "4h"
+	Interval6h      Interval = "6h"
+	Interval12h     Interval = "12h"
+	Interval24h     Interval = "24h"
+	Interval1d      Interval = "1d"
+	Interval2d      Interval = "2d"
+	Interval3d      Interval = "3d"
+	Interval7d      Interval = "7d"
+	Interval14d     Interval = "14d"
+	Interval15d     Interval = "15d"
+	Interval30d     Interval = "30d"
+	Interval60d     Interval = "60d"
+	Interval90d     Interval = "90d"
+	Interval365d    Interval = "365d"
+)
+
+// DurationInterval returns the Interval of length d, e.g. Interval1h for an
+// hour or Interval7d for a week. Durations without a matching interval yield a
+// value that fails validation.
+func DurationInterval(d time.Duration) Interval {
+	day := 24 * time.Hour
+	switch {
+	case d <= 0:
+		return Interval(d.String())
+	case d%day == 0:
+		return Interval(fmt.Sprintf("%dd", d/day))
+	case d%time.Hour == 0:
+		return Interval(fmt.Sprintf("%dh", d/time.Hour))
+	case d%time.Minute == 0:
+		return Interval(fmt.Sprintf("%dm", d/time.Minute))
+	}
+	return Interval(d.String())
+}
+
+// TimePeriod is the period of OHLCV candles or price performance stats.
+type TimePeriod string
+
+const (
+	TimePeriodHourly    TimePeriod = "hourly"
+	TimePeriodDaily     TimePeriod = "daily"
+	TimePeriodAllTime   TimePeriod = "all_time"
+	TimePeriodYesterday TimePeriod = "yesterday"
+	TimePeriod24h       TimePeriod = "24h"
+	TimePeriod7d        TimePeriod = "7d"
+	TimePeriod30d       TimePeriod = "30d"
+	TimePeriod90d       TimePeriod = "90d"
+	TimePeriod365d      TimePeriod = "365d"
+)
+
+type CryptocurrencyType string
+
+const (
+	CryptocurrencyTypeAll    CryptocurrencyType = "all"
+	CryptocurrencyTypeCoins  CryptocurrencyType = "coins"
+	CryptocurrencyTypeTokens CryptocurrencyType = "tokens"
+)
+
+// ListingTag filters the latest listings by tag.
+type ListingTag string
+
+const (
+	ListingTagAll         ListingTag = "all"
+	ListingTagDefi        ListingTag = "defi"
+	ListingTagFilesharing ListingTag = "filesharing"
+)
+
 type ApiPlan int
 
 const (
@@ -39,6 +208,41 @@ const (
 	Enterprise
 )
 
+// PlanLimits describes the quotas of an API plan.
+// Zero values mean that the respective quota is not enforced.
+type PlanLimits struct {
+	//Maximum number of calls per minute.
+	CallsPerMinute int
+
+	//Credits available per UTC day.
+	DailyCredits int
+
+	//Credits available per calendar month (UTC).
+	MonthlyCredits int
+}
+
+// RateLimit returns the per-minute call limit as a rate.Limit.
+func (p PlanLimits) RateLimit() rate.Limit {
+	if p.CallsPerMinute <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(float64(p.CallsPerMinute) / time.Minute.Seconds())
+}
+
+// APIPlanLimits holds the published quotas of every plan.
+// Enterprise contracts are negotiated individually, so only the call rate is
+// set for it; pass the actual limits of a contract as custom PlanLimits.
+var APIPlanLimits = map[ApiPlan]PlanLimits{
+	Free:         {CallsPerMinute: 30, DailyCredits: 333, MonthlyCredits: 10000},
+	Basic:        {CallsPerMinute: 30, DailyCredits: 333, MonthlyCredits: 10000},
+	Hobbyist:     {CallsPerMinute: 30, DailyCredits: 1333, MonthlyCredits: 40000},
+	Startup:      {CallsPerMinute: 30, DailyCredits: 4000, MonthlyCredits: 120000},
+	Standard:     {CallsPerMinute: 60, DailyCredits: 16667, MonthlyCredits: 500000},
+	Professional: {CallsPerMinute: 90, DailyCredits: 66667, MonthlyCredits: 2000000},
+	Enterprise:   {CallsPerMinute: 120},
+}
+
+// APIRateLimits holds the per-minute call limit of every plan.
 var APIRateLimits = map[ApiPlan]rate.Limit{
 	Free:         rate.Limit(30 / time.Minute.Seconds()),
 	Basic:        rate.Limit(30 / time.Minute.Seconds()),
@@ -46,4 +250,5 @@ var APIRateLimits = map[ApiPlan]rate.Limit{
 	Startup:      rate.Limit(30 / time.Minute.Seconds()),
 	Standard:     rate.Limit(60 / time.Minute.Seconds()),
 	Professional: rate.Limit(90 / time.Minute.Seconds()),
+	Enterprise:   rate.Limit(120 / time.Minute.Seconds()),
 }
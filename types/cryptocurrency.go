@@ -5,22 +5,28 @@ import "time"
 type CryptocurrencyInfoRequest struct {
 	//One or more comma-separated CoinMarketCap cryptocurrency IDs
 	Id string `url:"id,omitempty"`
+	//One or more CoinMarketCap cryptocurrency IDs, merged with Id.
+	Ids []int `url:"id,comma,omitempty"`
+
 	//A comma-separated list of cryptocurrency slugs
 	Slug string `url:"slug,omitempty"`
+	//A list of cryptocurrency slugs, merged with Slug.
+	Slugs []string `url:"slug,comma,omitempty"`
 
 	//One or more comma-separated cryptocurrency symbols
 	Symbol string `url:"symbol,omitempty"`
+	//One or more cryptocurrency symbols, merged with Symbol.
+	Symbols []string `url:"symbol,comma,omitempty"`
 
 	//Default: "urls,logo,description,tags,platform,date_added,notice"
 	//A comma-separated list of supplemental data fields to return.
 	//Pass urls,logo,description,tags,platform,date_added,notice,status to include all auxiliary fields.
 	Aux string `url:"aux,omitempty"`
+	//Supplemental data fields to return, merged with Aux. See CryptocurrencyInfoAuxFields.
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 }
 
-type CryptocurrencyInfoResponse struct {
-	Data   map[string]*CryptocurrencyInfo `json:"data"`
-	Status ResponseStatus                 `json:"status"`
-}
+type CryptocurrencyInfoResponse = Response[map[string]*CryptocurrencyInfo]
 
 type CryptocurrencyInfo struct {
 	//The unique CoinMarketCap ID for this cryptocurrency.
@@ -50,14 +56,14 @@ type CryptocurrencyInfo struct {
 	DateAdded *time.Time `json:"date_added"`
 
 	//A Markdown formatted notice that may highlight a significant event
-	//or condition that is impacting the cryptocurrency or how it is displayed.
-	Notice string `json:"notice"`
+	//or condition that is impacting the cryptocurrency or how it is displayed, otherwise null.
+	Notice *string `json:"notice"`
 
 	//Tags associated with this cryptocurrency.
 	Tags []string `json:"tags"`
 
-	//Metadata about the parent cryptocurrency platform this cryptocurrency belongs to if it is a token.
-	Platform Platform `json:"platform"`
+	//Metadata about the parent cryptocurrency platform this cryptocurrency belongs to if it is a token, otherwise null.
+	Platform *Platform `json:"platform"`
 
 	//Various resource URLs for this cryptocurrency.
 	Urls CryptocurrencyUrls `json:"urls"`
@@ -79,6 +85,8 @@ type CryptocurrencyMapRequest struct {
 	//Default: "active"
 	//One or more comma-separated values: "active", "inactive", "untracked".
 	ListingStatus string `url:"listing_status,omitempty"`
+	//One or more listing statuses, merged with ListingStatus.
+	ListingStatuses []ListingStatus `url:"listing_status,comma,omitempty"`
 
 	//Default: "1"
 	//Offset the start (1-based index) of the paginated list of items to return.
@@ -88,22 +96,23 @@ type CryptocurrencyMapRequest struct {
 	Limit int `url:"limit,omitempty"`
 
 	//Default: "id"
-	//Field to sort the list of cryptocurrencies by
-	Sort string `url:"sort,omitempty"`
+	//Field to sort the list of cryptocurrencies by, see CryptocurrencyMapSortFields.
+	Sort SortField `url:"sort,omitempty"`
 
 	//A comma-separated list of cryptocurrency symbols to return CoinMarketCap IDs for.
 	//If this option is passed, other options will be ignored.
 	Symbol string `url:"symbol,omitempty"`
+	//A list of cryptocurrency symbols, merged with Symbol.
+	Symbols []string `url:"symbol,comma,omitempty"`
 
 	//Default: "platform,first_historical_data,last_historical_data,is_active"
 	//A comma-separated list of supplemental data fields to return. Pass platform,first_historical_data,last_historical_data,is_active,status to include all auxiliary fields.
 	Aux string `url:"aux,omitempty"`
+	//Supplemental data fields to return, merged with Aux. See CryptocurrencyMapAuxFields.
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 }
 
-type CryptocurrencyMapResponse struct {
-	Data   []Cryptocurrency `json:"data"`
-	Status ResponseStatus   `json:"status"`
-}
+type CryptocurrencyMapResponse = Response[[]Cryptocurrency]
 
 type Cryptocurrency struct {
 	//The unique cryptocurrency ID for this cryptocurrency.
@@ -132,7 +141,7 @@ type Cryptocurrency struct {
 	LastHistoricalData *time.Time `json:"last_historical_data"`
 
 	//Metadata about the parent cryptocurrency platform this cryptocurrency belongs to if it is a token, otherwise null.
-	Platform Platform `json:"platform"`
+	Platform *Platform `json:"platform"`
 }
 
 type CryptocurrencyListing struct {
@@ -143,59 +152,62 @@ type CryptocurrencyListing struct {
 	CmcRank                int                    `json:"cmc_rank"`
 	NumMarketPairs         int                    `json:"num_market_pairs"`
 	CirculatingSupply      float64                `json:"circulating_supply"`
-	TotalSupply            float64                `json:"total_supply"`
+	TotalSupply            *float64               `json:"total_supply"`
 	MarketCapByTotalSupply float64                `json:"market_cap_by_total_supply"`
-	MaxSupply              float64                `json:"max_supply"`
+	MaxSupply              *float64               `json:"max_supply"`
 	LastUpdated            *time.Time             `json:"last_updated"`
 	DateAdded              *time.Time             `json:"date_added"`
 	Tags                   []string               `json:"tags"`
-	Platform               Platform               `json:"platform"`
+	Platform               *Platform              `json:"platform"`
 	Quote                  map[string]MarketQuote `json:"quote"`
 }
 
+// CryptocurrencyListingsHistoricalRequest selects the snapshot either by Date,
+// an ISO 8601 or Unix time string, or by Day, which is sent as Unix time.
 type CryptocurrencyListingsHistoricalRequest struct {
-	Date               string `url:"date,omitempty"`
-	Start              int    `url:"start,omitempty"`
-	Limit              int    `url:"limit,omitempty"`
-	Convert            string `url:"convert,omitempty"`
-	ConvertId          string `url:"convert_id,omitempty"`
-	Sort               string `url:"sort,omitempty"`
-	SortDir            string `url:"sort_dir,omitempty"`
-	CryptocurrencyType string `url:"cryptocurrency_type,omitempty"`
-	Aux                string `url:"aux,omitempty"`
+	Date               string             `url:"date,omitempty"`
+	Day                time.Time          `url:"date,unix,omitempty"`
+	Start              int                `url:"start,omitempty"`
+	Limit              int                `url:"limit,omitempty"`
+	Convert            string             `url:"convert,omitempty"`
+	ConvertSymbols     []string           `url:"convert,comma,omitempty"`
+	ConvertId          string             `url:"convert_id,omitempty"`
+	ConvertIds         []int              `url:"convert_id,comma,omitempty"`
+	Sort               SortField          `url:"sort,omitempty"`
+	SortDir            SortDirection      `url:"sort_dir,omitempty"`
+	CryptocurrencyType CryptocurrencyType `url:"cryptocurrency_type,omitempty"`
+	Aux                string             `url:"aux,omitempty"`
+	AuxFields          []AuxField         `url:"aux,comma,omitempty"`
 }
 
-type CryptocurrencyListingsHistoricalResponse struct {
-	Data   []CryptocurrencyListing
-	Status ResponseStatus
-}
+type CryptocurrencyListingsHistoricalResponse = Response[[]CryptocurrencyListing]
 
 type CryptocurrencyListingsLatestRequest struct {
-	Start                int     `url:"start,omitempty"`
-	Limit                int     `url:"limit,omitempty"`
-	PriceMin             float64 `url:"price_min,omitempty"`
-	PriceMax             float64 `url:"price_max,omitempty"`
-	MarketCapMin         float64 `url:"market_cap_min,omitempty"`
-	MarketCapMax         float64 `url:"market_cap_max,omitempty"`
-	Volume24HMin         float64 `url:"volume_24h_min,omitempty"`
-	Volume24HMax         float64 `url:"volume_24h_max,omitempty"`
-	CirculatingSupplyMin float64 `url:"circulating_supply_min,omitempty"`
-	CirculatingSupplyMax float64 `url:"circulating_supply_max,omitempty"`
-	PercentChange24HMin  float64 `url:"percent_change_24h_min,omitempty"`
-	PercentChange24HMax  float64 `url:"percent_change_24h_max,omitempty"`
-	Convert              string  `url:"convert,omitempty"`
-	ConvertId            string  `url:"convert_id,omitempty"`
-	Sort                 string  `url:"sort,omitempty"`
-	SortDir              string  `url:"sort_dir,omitempty"`
-	CryptocurrencyType   string  `url:"cryptocurrency_type,omitempty"`
-	Tag                  string  `url:"tag,omitempty"`
-	Aux                  string  `url:"aux,omitempty"`
+	Start                int                `url:"start,omitempty"`
+	Limit                int                `url:"limit,omitempty"`
+	PriceMin             float64            `url:"price_min,omitempty"`
+	PriceMax             float64            `url:"price_max,omitempty"`
+	MarketCapMin         float64            `url:"market_cap_min,omitempty"`
+	MarketCapMax         float64            `url:"market_cap_max,omitempty"`
+	Volume24HMin         float64            `url:"volume_24h_min,omitempty"`
+	Volume24HMax         float64            `url:"volume_24h_max,omitempty"`
+	CirculatingSupplyMin float64            `url:"circulating_supply_min,omitempty"`
+	CirculatingSupplyMax float64            `url:"circulating_supply_max,omitempty"`
+	PercentChange24HMin  float64            `url:"percent_change_24h_min,omitempty"`
+	PercentChange24HMax  float64            `url:"percent_change_24h_max,omitempty"`
+	Convert              string             `url:"convert,omitempty"`
+	ConvertSymbols       []string           `url:"convert,comma,omitempty"`
+	ConvertId            string             `url:"convert_id,omitempty"`
+	ConvertIds           []int              `url:"convert_id,comma,omitempty"`
+	Sort                 SortField          `url:"sort,omitempty"`
+	SortDir              SortDirection      `url:"sort_dir,omitempty"`
+	CryptocurrencyType   CryptocurrencyType `url:"cryptocurrency_type,omitempty"`
+	Tag                  ListingTag         `url:"tag,omitempty"`
+	Aux                  string             `url:"aux,omitempty"`
+	AuxFields            []AuxField         `url:"aux,comma,omitempty"`
 }
 
-type CryptocurrencyListingsLatestResponse struct {
-	Data   []CryptocurrencyListing
-	Status ResponseStatus
-}
+type CryptocurrencyListingsLatestResponse = Response[[]CryptocurrencyListing]
 
 type MarketQuote struct {
 	Price             float64    `json:"price"`
@@ -206,39 +218,48 @@ type MarketQuote struct {
 	Volume30D         float64    `json:"volume_30d"`
 	Volume30DReported float64    `json:"volume_30d_reported"`
 	MarketCap         float64    `json:"market_cap"`
-	PercentChange1H   float64    `json:"percent_change_1h"`
-	PercentChange24H  float64    `json:"percent_change_24h"`
-	PercentChange7D   float64    `json:"percent_change_7d"`
+	PercentChange1H   *float64   `json:"percent_change_1h"`
+	PercentChange24H  *float64   `json:"percent_change_24h"`
+	PercentChange7D   *float64   `json:"percent_change_7d"`
 	LastUpdated       *time.Time `json:"last_updated"`
 }
 
 type CryptocurrencyQuotesLatestRequest struct {
 	//One or more comma-separated cryptocurrency CoinMarketCap IDs.
 	Id string `url:"id,omitempty"`
+	//One or more cryptocurrency CoinMarketCap IDs, merged with Id.
+	Ids []int `url:"id,comma,omitempty"`
 
 	//One or more comma-separated cryptocurrency slugs
 	Slug string `url:"slug,omitempty"`
+	//One or more cryptocurrency slugs, merged with Slug.
+	Slugs []string `url:"slug,comma,omitempty"`
 
 	//One or more comma-separated cryptocurrency symbols.
 	Symbol string `url:"symbol,omitempty"`
+	//One or more cryptocurrency symbols, merged with Symbol.
+	Symbols []string `url:"symbol,comma,omitempty"`
 
 	//A comma-separated list of cryptocurrency or fiat currency symbols.
 	Convert string `url:"convert,omitempty"`
+	//A list of cryptocurrency or fiat currency symbols, merged with Convert.
+	ConvertSymbols []string `url:"convert,comma,omitempty"`
 
 	//A comma-separated list of CoinMarketCap IDs.
 	ConvertId string `url:"convert_id,omitempty"`
+	//A list of CoinMarketCap IDs, merged with ConvertId.
+	ConvertIds []int `url:"convert_id,comma,omitempty"`
 
 	//A comma-separated list of supplemental data fields to return
 	Aux string `url:"aux,omitempty"`
+	//Supplemental data fields to return, merged with Aux. See CryptocurrencyQuotesLatestAuxFields.
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 
 	//If set to true, invalid lookups will be skipped allowing valid cryptocurrencies to still be returned.
 	SkipInvalid bool `url:"skip_invalid,omitempty"`
 }
 
-type CryptocurrencyQuotesLatestResponse struct {
-	Data   map[string]CryptocurrencyQuote `json:"data"`
-	Status ResponseStatus                 `json:"status"`
-}
+type CryptocurrencyQuotesLatestResponse = Response[map[string]CryptocurrencyQuote]
 
 type CryptocurrencyQuote struct {
 	//The unique CoinMarketCap ID for the parent platform cryptocurrency.
@@ -267,13 +288,13 @@ type CryptocurrencyQuote struct {
 	CirculatingSupply float64 `json:"circulating_supply"`
 
 	//The approximate total amount of coins in existence right now (minus any coins that have been verifiably burned).
-	TotalSupply float64 `json:"total_supply"`
+	TotalSupply *float64 `json:"total_supply"`
 
 	//The market cap by total supply. This field is only returned if requested through the aux request parameter.
 	MarketCapByTotalSupply float64 `json:"market_cap_by_total_supply"`
 
-	//The expected maximum limit of coins ever to be available for this cryptocurrency.
-	MaxSupply float64 `json:"max_supply"`
+	//The expected maximum limit of coins ever to be available for this cryptocurrency, null if there is none.
+	MaxSupply *float64 `json:"max_supply"`
 
 	//Timestamp (ISO 8601) of when this cryptocurrency was added to CoinMarketCap.
 	DateAdded *time.Time `json:"date_added"`
@@ -282,7 +303,7 @@ type CryptocurrencyQuote struct {
 	Tags []string `json:"tags"`
 
 	//Metadata about the parent cryptocurrency platform this cryptocurrency belongs to if it is a token, otherwise null.
-	Platform Platform `json:"platform"`
+	Platform *Platform `json:"platform"`
 
 	//Timestamp (ISO 8601) of the last time this cryptocurrency's market data was updated.
 	LastUpdated *time.Time `json:"last_updated"`
@@ -291,36 +312,44 @@ type CryptocurrencyQuote struct {
 }
 
 type CryptocurrencyOHLCVLatestRequest struct {
-	Id          string `url:"id,omitempty"`
-	Symbol      string `url:"symbol,omitempty"`
-	Convert     string `url:"convert,omitempty"`
-	ConvertId   string `url:"convert_id,omitempty"`
-	SkipInvalid string `url:"skip_invalid,omitempty"`
+	Id             string   `url:"id,omitempty"`
+	Ids            []int    `url:"id,comma,omitempty"`
+	Symbol         string   `url:"symbol,omitempty"`
+	Symbols        []string `url:"symbol,comma,omitempty"`
+	Convert        string   `url:"convert,omitempty"`
+	ConvertSymbols []string `url:"convert,comma,omitempty"`
+	ConvertId      string   `url:"convert_id,omitempty"`
+	ConvertIds     []int    `url:"convert_id,comma,omitempty"`
+	SkipInvalid    string   `url:"skip_invalid,omitempty"`
 }
 
-type CryptocurrencyOHLCVLatestResponse struct {
-	Data   map[string]*CryptocurrencyOHLCV `json:"data"`
-	Status ResponseStatus                  `json:"status"`
-}
+type CryptocurrencyOHLCVLatestResponse = Response[map[string]*CryptocurrencyOHLCV]
 
+// CryptocurrencyOHLCVHistoricalRequest takes its time range either as
+// TimeStart and TimeEnd strings or as StartTime and EndTime, which are sent as
+// Unix time. DurationInterval converts a time.Duration into an Interval.
 type CryptocurrencyOHLCVHistoricalRequest struct {
-	Id          string `url:"id,omitempty"`
-	Slug        string `url:"slug,omitempty"`
-	Symbol      string `url:"symbol,omitempty"`
-	TimePeriod  string `url:"time_period,omitempty"`
-	TimeStart   string `url:"time_start,omitempty"`
-	TimeEnd     string `url:"time_end,omitempty"`
-	Count       int    `url:"count,omitempty"`
-	Interval    string `url:"interval,omitempty"`
-	Convert     string `url:"convert,omitempty"`
-	ConvertId   string `url:"convert_id,omitempty"`
-	SkipInvalid string `url:"skip_invalid,omitempty"`
+	Id             string     `url:"id,omitempty"`
+	Ids            []int      `url:"id,comma,omitempty"`
+	Slug           string     `url:"slug,omitempty"`
+	Slugs          []string   `url:"slug,comma,omitempty"`
+	Symbol         string     `url:"symbol,omitempty"`
+	Symbols        []string   `url:"symbol,comma,omitempty"`
+	TimePeriod     TimePeriod `url:"time_period,omitempty"`
+	TimeStart      string     `url:"time_start,omitempty"`
+	TimeEnd        string     `url:"time_end,omitempty"`
+	StartTime      time.Time  `url:"time_start,unix,omitempty"`
+	EndTime        time.Time  `url:"time_end,unix,omitempty"`
+	Count          int        `url:"count,omitempty"`
+	Interval       Interval   `url:"interval,omitempty"`
+	Convert        string     `url:"convert,omitempty"`
+	ConvertSymbols []string   `url:"convert,comma,omitempty"`
+	ConvertId      string     `url:"convert_id,omitempty"`
+	ConvertIds     []int      `url:"convert_id,comma,omitempty"`
+	SkipInvalid    string     `url:"skip_invalid,omitempty"`
 }
 
-type CryptocurrencyOHLCVHistoricalResponse struct {
-	Data   map[string]*OHLCVHistoricalResult `json:"data"`
-	Status ResponseStatus                    `json:"status"`
-}
+type CryptocurrencyOHLCVHistoricalResponse = Response[map[string]*OHLCVHistoricalResult]
 
 type OHLCVHistoricalResult struct {
 	Id     int    `json:"id"`
@@ -355,18 +384,21 @@ type OHLCV struct {
 }
 
 type CryptocurrencyPricePerformanceStatsRequest struct {
-	Id         string `url:"id,omitempty"`
-	Slug       string `url:"slug,omitempty"`
-	Symbol     string `url:"symbol,omitempty"`
-	TimePeriod string `url:"time_period,omitempty"`
-	Convert    string `url:"convert,omitempty"`
-	ConvertId  string `url:"convert_id,omitempty"`
+	Id             string       `url:"id,omitempty"`
+	Ids            []int        `url:"id,comma,omitempty"`
+	Slug           string       `url:"slug,omitempty"`
+	Slugs          []string     `url:"slug,comma,omitempty"`
+	Symbol         string       `url:"symbol,omitempty"`
+	Symbols        []string     `url:"symbol,comma,omitempty"`
+	TimePeriod     string       `url:"time_period,omitempty"`
+	TimePeriods    []TimePeriod `url:"time_period,comma,omitempty"`
+	Convert        string       `url:"convert,omitempty"`
+	ConvertSymbols []string     `url:"convert,comma,omitempty"`
+	ConvertId      string       `url:"convert_id,omitempty"`
+	ConvertIds     []int        `url:"convert_id,comma,omitempty"`
 }
 
-type CryptocurrencyPricePerformanceStatsResponse struct {
-	Data   map[string]*PricePerformanceStats `json:"data"`
-	Status ResponseStatus                    `json:"status"`
-}
+type CryptocurrencyPricePerformanceStatsResponse = Response[map[string]*PricePerformanceStats]
 
 type PricePerformanceStats struct {
 	Id          int                `json:"id"`
@@ -378,10 +410,10 @@ type PricePerformanceStats struct {
 }
 
 type Period struct {
-	OpenTimestamp  *time.Time            `json:"open_timestamp"`
-	HighTimestamp  *time.Time            `json:"high_timestamp"`
-	LowTimestamp   *time.Time            `json:"low_timestamp"`
-	CloseTimestamp *time.Time            `json:"close_timestamp"`
+	OpenTimestamp  *time.Time             `json:"open_timestamp"`
+	HighTimestamp  *time.Time             `json:"high_timestamp"`
+	LowTimestamp   *time.Time             `json:"low_timestamp"`
+	CloseTimestamp *time.Time             `json:"close_timestamp"`
 	Quote          map[string]*StatsQuote `json:"quote"`
 }
 
@@ -394,6 +426,6 @@ type StatsQuote struct {
 	LowTimestamp   *time.Time `json:"low_timestamp"`
 	Close          float64    `json:"close"`
 	CloseTimestamp *time.Time `json:"close_timestamp"`
-	PercentChange  float64    `json:"percent_change"`
+	PercentChange  *float64   `json:"percent_change"`
 	PriceChange    float64    `json:"price_change"`
 }
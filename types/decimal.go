@@ -0,0 +1,179 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decimal is an arbitrary-precision number that keeps the exact digits it
+// was decoded from. The zero value is 0.
+type Decimal struct {
+	digits string
+}
+
+// NewDecimal parses a decimal number such as "0.000000001234" or "1.5e-12".
+func NewDecimal(s string) (Decimal, error) {
+	if strings.Trim(s, "0123456789.eE+-") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if _, ok := new(big.Rat).SetString(s); !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{digits: s}, nil
+}
+
+// String returns the digits of d as they were decoded.
+func (d Decimal) String() string {
+	if d.digits == "" {
+		return "0"
+	}
+	return d.digits
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Float64Exact is Float64, additionally reporting whether the float64
+// represents d exactly.
+func (d Decimal) Float64Exact() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Cmp compares d and other and returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// IsZero reports whether d equals 0.
+func (d Decimal) IsZero() bool {
+	return d.Rat().Sign() == 0
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers and numeric strings. null decodes to 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	decimal, err := NewDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = decimal
+	return nil
+}
+
+// The Decimal variants of the market data types below decode prices, volumes,
+// market caps and supplies into Decimal. They are returned by the Decimal
+// methods of the client.
+
+type MarketQuoteDecimal struct {
+	Price             Decimal    `json:"price"`
+	Volume24H         Decimal    `json:"volume_24h"`
+	Volume24HReported Decimal    `json:"volume_24h_reported"`
+	Volume7d          Decimal    `json:"volume_7d"`
+	Volume7dReported  Decimal    `json:"volume_7d_reported"`
+	Volume30D         Decimal    `json:"volume_30d"`
+	Volume30DReported Decimal    `json:"volume_30d_reported"`
+	MarketCap         Decimal    `json:"market_cap"`
+	PercentChange1H   *float64   `json:"percent_change_1h"`
+	PercentChange24H  *float64   `json:"percent_change_24h"`
+	PercentChange7D   *float64   `json:"percent_change_7d"`
+	LastUpdated       *time.Time `json:"last_updated"`
+}
+
+type CryptocurrencyListingDecimal struct {
+	Id                     int                           `json:"id"`
+	Name                   string                        `json:"name"`
+	Symbol                 string                        `json:"symbol"`
+	Slug                   string                        `json:"slug"`
+	CmcRank                int                           `json:"cmc_rank"`
+	NumMarketPairs         int                           `json:"num_market_pairs"`
+	CirculatingSupply      Decimal                       `json:"circulating_supply"`
+	TotalSupply            *Decimal                      `json:"total_supply"`
+	MarketCapByTotalSupply Decimal                       `json:"market_cap_by_total_supply"`
+	MaxSupply              *Decimal                      `json:"max_supply"`
+	LastUpdated            *time.Time                    `json:"last_updated"`
+	DateAdded              *time.Time                    `json:"date_added"`
+	Tags                   []string                      `json:"tags"`
+	Platform               *Platform                     `json:"platform"`
+	Quote                  map[string]MarketQuoteDecimal `json:"quote"`
+}
+
+type CryptocurrencyQuoteDecimal struct {
+	Id                     int                           `json:"id"`
+	Name                   string                        `json:"name"`
+	Symbol                 string                        `json:"symbol"`
+	Slug                   string                        `json:"slug"`
+	IsActive               CryptocurrencyStatus          `json:"is_active"`
+	IsFiat                 int                           `json:"is_fiat"`
+	CmcRank                int                           `json:"cmc_rank"`
+	NumMarketPairs         int                           `json:"num_market_pairs"`
+	CirculatingSupply      Decimal                       `json:"circulating_supply"`
+	TotalSupply            *Decimal                      `json:"total_supply"`
+	MarketCapByTotalSupply Decimal                       `json:"market_cap_by_total_supply"`
+	MaxSupply              *Decimal                      `json:"max_supply"`
+	DateAdded              *time.Time                    `json:"date_added"`
+	Tags                   []string                      `json:"tags"`
+	Platform               *Platform                     `json:"platform"`
+	LastUpdated            *time.Time                    `json:"last_updated"`
+	Quote                  map[string]MarketQuoteDecimal `json:"quote"`
+}
+
+type OHLCVDecimal struct {
+	Open        Decimal    `json:"open"`
+	High        Decimal    `json:"high"`
+	Low         Decimal    `json:"low"`
+	Close       Decimal    `json:"close"`
+	Volume      Decimal    `json:"volume"`
+	LastUpdated *time.Time `json:"last_updated"`
+}
+
+type OHLCVQuoteDecimal struct {
+	TimeOpen  *time.Time               `json:"time_open"`
+	TimeHigh  *time.Time               `json:"time_high"`
+	TimeLow   *time.Time               `json:"time_low"`
+	TimeClose *time.Time               `json:"time_close"`
+	Quote     map[string]*OHLCVDecimal `json:"quote"`
+}
+
+type CryptocurrencyOHLCVDecimal struct {
+	Id          int        `json:"id"`
+	Name        string     `json:"name"`
+	Symbol      string     `json:"symbol"`
+	LastUpdated *time.Time `json:"last_updated"`
+	OHLCVQuoteDecimal
+}
+
+type OHLCVHistoricalResultDecimal struct {
+	Id     int    `json:"id"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+	Quotes []OHLCVQuoteDecimal
+}
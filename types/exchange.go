@@ -3,15 +3,15 @@ package types
 import "time"
 
 type ExchangeInfoRequest struct {
-	Id   string `url:"id,omitempty"`
-	Slug string `url:"slug,omitempty"`
-	Aux  string `url:"aux,omitempty"`
+	Id        string     `url:"id,omitempty"`
+	Ids       []int      `url:"id,comma,omitempty"`
+	Slug      string     `url:"slug,omitempty"`
+	Slugs     []string   `url:"slug,comma,omitempty"`
+	Aux       string     `url:"aux,omitempty"`
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 }
 
-type ExchangeInfoResponse struct {
-	Data   map[string]*ExchangeInfo `json:"data"`
-	Status ResponseStatus           `json:"status"`
-}
+type ExchangeInfoResponse = Response[map[string]*ExchangeInfo]
 
 type ExchangeInfo struct {
 	Id           int          `json:"id"`
@@ -20,7 +20,7 @@ type ExchangeInfo struct {
 	Logo         string       `json:"logo"`
 	Description  string       `json:"description"`
 	DateLaunched *time.Time   `json:"date_launched"`
-	Notice       string       `json:"notice"`
+	Notice       *string      `json:"notice"`
 	Urls         ExchangeUrls `json:"urls"`
 }
 
@@ -33,18 +33,18 @@ type ExchangeUrls struct {
 }
 
 type ExchangeIdMapRequest struct {
-	ListingStatus ExchangeStatus `url:"listing_status,omitempty"`
-	Slug          string         `url:"slug,omitempty"`
-	Start         int            `url:"start,omitempty"`
-	Limit         int            `url:"limit,omitempty"`
-	Sort          string         `url:"sort,omitempty"`
-	Aux           string         `url:"aux,omitempty"`
+	ListingStatus   ExchangeStatus   `url:"listing_status,omitempty"`
+	ListingStatuses []ExchangeStatus `url:"listing_status,comma,omitempty"`
+	Slug            string           `url:"slug,omitempty"`
+	Slugs           []string         `url:"slug,comma,omitempty"`
+	Start           int              `url:"start,omitempty"`
+	Limit           int              `url:"limit,omitempty"`
+	Sort            SortField        `url:"sort,omitempty"`
+	Aux             string           `url:"aux,omitempty"`
+	AuxFields       []AuxField       `url:"aux,comma,omitempty"`
 }
 
-type ExchangeIdMapResponse struct {
-	Data   []Exchange     `json:"data"`
-	Status ResponseStatus `json:"status"`
-}
+type ExchangeIdMapResponse = Response[[]Exchange]
 
 type Exchange struct {
 	Id             int                       `json:"id"`
@@ -61,8 +61,8 @@ type ExchangeQuote struct {
 	Volume7d               float64    `json:"volume_7d"`
 	Volume30D              float64    `json:"volume_30d"`
 	MarketCap              float64    `json:"market_cap"`
-	PercentChangeVolume24H float64    `json:"percent_change_volume_24h"`
-	PercentChangeVolume7D  float64    `json:"percent_change_volume_7d"`
-	PercentChangeVolume30D float64    `json:"percent_change_volume_30d"`
+	PercentChangeVolume24H *float64   `json:"percent_change_volume_24h"`
+	PercentChangeVolume7D  *float64   `json:"percent_change_volume_7d"`
+	PercentChangeVolume30D *float64   `json:"percent_change_volume_30d"`
 	LastUpdated            *time.Time `json:"last_updated"`
 }
@@ -1,16 +1,13 @@
 package types
 
 type FiatMapRequest struct {
-	Start         int    `url:"start,omitempty"`
-	Limit         int    `url:"limit,omitempty"`
-	Sort          string `url:"sort,omitempty"`
-	IncludeMetals bool   `url:"include_metals,omitempty"`
+	Start         int       `url:"start,omitempty"`
+	Limit         int       `url:"limit,omitempty"`
+	Sort          SortField `url:"sort,omitempty"`
+	IncludeMetals bool      `url:"include_metals,omitempty"`
 }
 
-type FiatMapResponse struct {
-	Data   []Fiat         `json:"data"`
-	Status ResponseStatus `json:"status"`
-}
+type FiatMapResponse = Response[[]Fiat]
 
 type Fiat struct {
 	//The unique CoinMarketCap ID for this asset.
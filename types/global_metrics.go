@@ -5,15 +5,16 @@ import "time"
 type GlobalMetricsQuotesLatestRequest struct {
 	//A comma-separated list of cryptocurrency or fiat currency symbols.
 	Convert string `url:"convert,omitempty"`
+	//A list of cryptocurrency or fiat currency symbols, merged with Convert.
+	ConvertSymbols []string `url:"convert,comma,omitempty"`
 
 	//A comma-separated list of CoinMarketCap IDs.
 	ConvertId string `url:"convert_id,omitempty"`
+	//A list of CoinMarketCap IDs, merged with ConvertId.
+	ConvertIds []int `url:"convert_id,comma,omitempty"`
 }
 
-type GlobalMetricsQuotesLatestResponse struct {
-	Data   GlobalMetricsQuotesLatest `json:"data"`
-	Status ResponseStatus            `json:"status"`
-}
+type GlobalMetricsQuotesLatestResponse = Response[GlobalMetricsQuotesLatest]
 
 type GlobalMetricsQuotesLatest struct {
 	BTCDominance           float64                        `json:"btc_dominance"`
@@ -37,21 +38,28 @@ type GlobalMetricsQuote struct {
 	LastUpdated              *time.Time `json:"last_updated"`
 }
 
+// GlobalMetricsQuotesHistoricalRequest takes its time range either as
+// TimeStart and TimeEnd strings or as StartTime and EndTime, which are sent as
+// Unix time. DurationInterval converts a time.Duration into an Interval.
 type GlobalMetricsQuotesHistoricalRequest struct {
-	TimeStart string `url:"time_start,omitempty"`
-	TimeEnd   string `url:"time_end,omitempty"`
-	Count     int    `url:"count,omitempty"`
-	Interval  string `url:"interval,omitempty"`
-	Convert   string `url:"convert,omitempty"`
-	ConvertId string `url:"convert_id,omitempty"`
-	Aux       string `url:"aux,omitempty"`
+	TimeStart      string     `url:"time_start,omitempty"`
+	TimeEnd        string     `url:"time_end,omitempty"`
+	StartTime      time.Time  `url:"time_start,unix,omitempty"`
+	EndTime        time.Time  `url:"time_end,unix,omitempty"`
+	Count          int        `url:"count,omitempty"`
+	Interval       Interval   `url:"interval,omitempty"`
+	Convert        string     `url:"convert,omitempty"`
+	ConvertSymbols []string   `url:"convert,comma,omitempty"`
+	ConvertId      string     `url:"convert_id,omitempty"`
+	ConvertIds     []int      `url:"convert_id,comma,omitempty"`
+	Aux            string     `url:"aux,omitempty"`
+	AuxFields      []AuxField `url:"aux,comma,omitempty"`
 }
 
-type GlobalMetricsQuotesHistoricalResponse struct {
-	Data struct {
-		Quotes []AggregatedMarketQuote `json:"quotes"`
-	} `json:"data"`
-	Status ResponseStatus `json:"status"`
+type GlobalMetricsQuotesHistoricalResponse = Response[GlobalMetricsQuotesHistorical]
+
+type GlobalMetricsQuotesHistorical struct {
+	Quotes []AggregatedMarketQuote `json:"quotes"`
 }
 
 type AggregatedMarketQuote struct {
@@ -3,15 +3,13 @@ package types
 import "time"
 
 type FCASListingsLatestRequest struct {
-	Start int    `url:"start,omitempty"`
-	Limit int    `url:"limit,omitempty"`
-	Aux   string `url:"aux,omitempty"`
+	Start     int        `url:"start,omitempty"`
+	Limit     int        `url:"limit,omitempty"`
+	Aux       string     `url:"aux,omitempty"`
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 }
 
-type FCASListingsLatestResponse struct {
-	Data   []FCASRating   `json:"data"`
-	Status ResponseStatus `json:"status"`
-}
+type FCASListingsLatestResponse = Response[[]FCASRating]
 
 type FCASRating struct {
 	Id               int        `json:"id"`
@@ -20,19 +18,20 @@ type FCASRating struct {
 	Slug             string     `json:"slug"`
 	Score            int        `json:"score"`
 	Grade            string     `json:"grade"`
-	PercentChange24H float64    `json:"percent_change_24h"`
+	PercentChange24H *float64   `json:"percent_change_24h"`
 	PointChange24H   float64    `json:"point_change_24h"`
 	LastUpdated      *time.Time `json:"last_updated"`
 }
 
 type FCASQuotesLatestRequest struct {
-	Id     string `url:"id,omitempty"`
-	Slug   string `url:"slug,omitempty"`
-	Symbol string `url:"symbol,omitempty"`
-	Aux    string `url:"aux,omitempty"`
+	Id        string     `url:"id,omitempty"`
+	Ids       []int      `url:"id,comma,omitempty"`
+	Slug      string     `url:"slug,omitempty"`
+	Slugs     []string   `url:"slug,comma,omitempty"`
+	Symbol    string     `url:"symbol,omitempty"`
+	Symbols   []string   `url:"symbol,comma,omitempty"`
+	Aux       string     `url:"aux,omitempty"`
+	AuxFields []AuxField `url:"aux,comma,omitempty"`
 }
 
-type FCASQuotesLatestResponse struct {
-	Data   map[string]*FCASRating `json:"data"`
-	Status ResponseStatus         `json:"status"`
-}
+type FCASQuotesLatestResponse = Response[map[string]*FCASRating]
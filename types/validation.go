@@ -0,0 +1,480 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option values supported by each endpoint, as documented by CoinMarketCap.
+var (
+	CryptocurrencyInfoAuxFields = []AuxField{
+		AuxUrls, AuxLogo, AuxDescription, AuxTags, AuxPlatform, AuxDateAdded, AuxNotice, AuxStatus,
+	}
+	CryptocurrencyMapAuxFields = []AuxField{
+		AuxPlatform, AuxFirstHistoricalData, AuxLastHistoricalData, AuxIsActive, AuxStatus,
+	}
+	CryptocurrencyListingsHistoricalAuxFields = []AuxField{
+		AuxPlatform, AuxTags, AuxDateAdded, AuxCirculatingSupply, AuxTotalSupply, AuxMaxSupply,
+		AuxCmcRank, AuxNumMarketPairs,
+	}
+	CryptocurrencyListingsLatestAuxFields = []AuxField{
+		AuxNumMarketPairs, AuxCmcRank, AuxDateAdded, AuxTags, AuxPlatform, AuxMaxSupply,
+		AuxCirculatingSupply, AuxTotalSupply, AuxMarketCapByTotalSupply, AuxVolume24HReported,
+		AuxVolume7D, AuxVolume7DReported, AuxVolume30D, AuxVolume30DReported, AuxIsMarketCapIncludedInCalc,
+	}
+	CryptocurrencyQuotesLatestAuxFields = []AuxField{
+		AuxNumMarketPairs, AuxCmcRank, AuxDateAdded, AuxTags, AuxPlatform, AuxMaxSupply,
+		AuxCirculatingSupply, AuxTotalSupply, AuxMarketCapByTotalSupply, AuxVolume24HReported,
+		AuxVolume7D, AuxVolume7DReported, AuxVolume30D, AuxVolume30DReported, AuxIsActive, AuxIsFiat,
+	}
+	ExchangeInfoAuxFields = []AuxField{
+		AuxUrls, AuxLogo, AuxDescription, AuxDateLaunched, AuxNotice, AuxStatus,
+	}
+	ExchangeIdMapAuxFields = []AuxField{
+		AuxFirstHistoricalData, AuxLastHistoricalData, AuxIsActive, AuxStatus,
+	}
+	GlobalMetricsQuotesHistoricalAuxFields = []AuxField{
+		AuxBTCDominance, AuxActiveCryptocurrencies, AuxActiveExchanges, AuxActiveMarketPairs,
+		AuxTotalVolume24H, AuxTotalVolume24HReported, AuxAltcoinMarketCap, AuxAltcoinVolume24H,
+		AuxAltcoinVolume24HReported, AuxSearchInterval,
+	}
+	FCASAuxFields = []AuxField{
+		AuxPointChange24H, AuxPercentChange24H,
+	}
+
+	CryptocurrencyMapSortFields = []SortField{
+		SortId, SortCmcRank,
+	}
+	CryptocurrencyListingsHistoricalSortFields = []SortField{
+		SortCmcRank, SortName, SortSymbol, SortMarketCap, SortPrice, SortCirculatingSupply,
+		SortTotalSupply, SortMaxSupply, SortNumMarketPairs, SortVolume24H, SortPercentChange1H,
+		SortPercentChange24H, SortPercentChange7D,
+	}
+	CryptocurrencyListingsLatestSortFields = []SortField{
+		SortMarketCap, SortName, SortSymbol, SortDateAdded, SortMarketCapStrict, SortPrice,
+		SortCirculatingSupply, SortTotalSupply, SortMaxSupply, SortNumMarketPairs, SortVolume24H,
+		SortPercentChange1H, SortPercentChange24H, SortPercentChange7D,
+		SortMarketCapByTotalSupplyStrict, SortVolume7D, SortVolume30D,
+	}
+	ExchangeIdMapSortFields = []SortField{
+		SortVolume24H, SortId,
+	}
+	FiatMapSortFields = []SortField{
+		SortName, SortId,
+	}
+
+	SortDirections = []SortDirection{SortAsc, SortDesc}
+
+	CryptocurrencyTypes = []CryptocurrencyType{
+		CryptocurrencyTypeAll, CryptocurrencyTypeCoins, CryptocurrencyTypeTokens,
+	}
+	ListingTags = []ListingTag{
+		ListingTagAll, ListingTagDefi, ListingTagFilesharing,
+	}
+	ListingStatuses = []ListingStatus{
+		ListingActive, ListingInActive, ListingUntracked,
+	}
+	ExchangeStatuses = []ExchangeStatus{
+		ExchangeActive, ExchangeInActive, ExchangeUntracked,
+	}
+
+	OHLCVHistoricalIntervals = []Interval{
+		IntervalHourly, IntervalDaily, IntervalWeekly, IntervalMonthly, IntervalYearly,
+		Interval1h, Interval2h, Interval3h, Interval4h, Interval6h, Interval12h,
+		Interval1d, Interval2d, Interval3d, Interval7d, Interval14d, Interval15d,
+		Interval30d, Interval60d, Interval90d, Interval365d,
+	}
+	GlobalMetricsHistoricalIntervals = []Interval{
+		IntervalHourly, IntervalDaily, IntervalWeekly, IntervalMonthly, IntervalYearly,
+		Interval5m, Interval10m, Interval15m, Interval30m, Interval45m,
+		Interval1h, Interval2h, Interval3h, Interval4h, Interval6h, Interval12h, Interval24h,
+		Interval1d, Interval2d, Interval3d, Interval7d, Interval14d, Interval15d,
+		Interval30d, Interval60d, Interval90d, Interval365d,
+	}
+
+	OHLCVHistoricalTimePeriods = []TimePeriod{
+		TimePeriodHourly, TimePeriodDaily,
+	}
+	PricePerformanceTimePeriods = []TimePeriod{
+		TimePeriodAllTime, TimePeriodYesterday, TimePeriod24h, TimePeriod7d,
+		TimePeriod30d, TimePeriod90d, TimePeriod365d,
+	}
+)
+
+var (
+	// ErrInvalidRequest is matched by errors.Is for every ValidationError.
+	ErrInvalidRequest = errors.New("coinmarketcap: invalid request")
+
+	// ErrInvalidParameter is matched by errors.Is both for a ValidationError
+	// and for a 400 Bad Request of the API, so invalid parameters are detected
+	// the same way wherever they are caught. It is re-exported by the client
+	// package.
+	ErrInvalidParameter = errors.New("coinmarketcap: invalid parameter")
+)
+
+// FieldError describes a request parameter that is invalid on its own or in
+// combination with others.
+type FieldError struct {
+	//Name of the query parameter, e.g. "aux".
+	Field string
+
+	//The offending value, empty if the parameter is missing or its value is
+	//not at fault.
+	Value string
+
+	//Why the value was rejected.
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidationError is returned by the Validate methods of the requests and
+// lists every rejected parameter.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidRequest, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRequest || target == ErrInvalidParameter
+}
+
+// validator collects the field errors of a request.
+type validator struct {
+	errs []*FieldError
+}
+
+func (v *validator) add(field string, value string, reason string) {
+	v.errs = append(v.errs, &FieldError{Field: field, Value: value, Reason: reason})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+// oneOf checks that value is empty or one of supported.
+func oneOf[T ~string](v *validator, field string, value T, supported []T) {
+	if value == "" {
+		return
+	}
+	for _, s := range supported {
+		if value == s {
+			return
+		}
+	}
+	v.add(field, string(value), "not supported by this endpoint")
+}
+
+// allOf checks that every item of a parameter given both as comma-separated
+// list and as typed values is one of supported.
+func allOf[T ~string](v *validator, field string, list string, values []T, supported []T) {
+	for _, item := range strings.Split(list, ",") {
+		oneOf(v, field, T(strings.TrimSpace(item)), supported)
+	}
+	for _, value := range values {
+		oneOf(v, field, value, supported)
+	}
+}
+
+// exclusive checks that a parameter is not given both as string and as time.
+func exclusive(v *validator, field string, value string, t time.Time) {
+	if value != "" && !t.IsZero() {
+		v.add(field, value, "given both as string and as time.Time")
+	}
+}
+
+// timeRange checks the time fields of a historical request and that the
+// range they span is not empty.
+func timeRange(v *validator, timeStart string, timeEnd string, start time.Time, end time.Time) {
+	exclusive(v, "time_start", timeStart, start)
+	exclusive(v, "time_end", timeEnd, end)
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		v.add("time_end", end.UTC().Format(time.RFC3339), "must be after time_start")
+	}
+}
+
+// MaxLimit is the largest page size of the paginated endpoints.
+const MaxLimit = 5000
+
+// MaxCount is the largest number of time periods of the historical endpoints.
+const MaxCount = 10000
+
+// param is a request parameter that may be given through several fields.
+type param struct {
+	name string
+	set  bool
+}
+
+// exactlyOne checks that exactly one of the alternative params is set.
+func exactlyOne(v *validator, params ...param) {
+	var set []string
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.name
+		if p.set {
+			set = append(set, p.name)
+		}
+	}
+
+	switch {
+	case len(set) == 0:
+		v.add(names[0], "", fmt.Sprintf("one of %s is required", strings.Join(names, ", ")))
+	case len(set) > 1:
+		for _, name := range set[1:] {
+			v.add(name, "", fmt.Sprintf("cannot be combined with %s", set[0]))
+		}
+	}
+}
+
+// atLeast checks that an optional integer parameter is not below min.
+func atLeast(v *validator, field string, value int, min int) {
+	if value != 0 && value < min {
+		v.add(field, strconv.Itoa(value), fmt.Sprintf("must be at least %d", min))
+	}
+}
+
+// inRange checks that an optional integer parameter lies within [min..max].
+func inRange(v *validator, field string, value int, min int, max int) {
+	if value != 0 && (value < min || value > max) {
+		v.add(field, strconv.Itoa(value), fmt.Sprintf("must be within [%d..%d]", min, max))
+	}
+}
+
+// page checks the start and limit parameters of a paginated request.
+func page(v *validator, start int, limit int) {
+	atLeast(v, "start", start, 1)
+	inRange(v, "limit", limit, 1, MaxLimit)
+}
+
+// minMax checks that the bounds of an optional range filter are ordered.
+func minMax(v *validator, field string, min float64, max float64) {
+	if min != 0 && max != 0 && min > max {
+		v.add(field+"_max", strconv.FormatFloat(max, 'f', -1, 64), fmt.Sprintf("must not be below %s_min", field))
+	}
+}
+
+// ids checks that every item of a list of CoinMarketCap IDs, given both as
+// comma-separated list and as integers, is a positive integer.
+func ids(v *validator, field string, list string, values []int) {
+	if list != "" {
+		for _, item := range strings.Split(list, ",") {
+			item = strings.TrimSpace(item)
+			if id, err := strconv.Atoi(item); err != nil || id < 1 {
+				v.add(field, item, "must be a positive integer")
+			}
+		}
+	}
+	for _, id := range values {
+		if id < 1 {
+			v.add(field, strconv.Itoa(id), "must be a positive integer")
+		}
+	}
+}
+
+// count checks the count parameter of a historical request, which the API
+// rejects when the time range is given on both ends.
+func count(v *validator, value int, hasStart bool, hasEnd bool) {
+	inRange(v, "count", value, 1, MaxCount)
+	if value != 0 && hasStart && hasEnd {
+		v.add("count", strconv.Itoa(value), "cannot be combined with both time_start and time_end")
+	}
+}
+
+// convert checks the conversion targets shared by the market data requests.
+func convert(v *validator, convertId string, convertIds []int) {
+	ids(v, "convert_id", convertId, convertIds)
+}
+
+func (r *CryptocurrencyInfoRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	allOf(&v, "aux", r.Aux, r.AuxFields, CryptocurrencyInfoAuxFields)
+	return v.err()
+}
+
+func (r *CryptocurrencyMapRequest) Validate() error {
+	var v validator
+	allOf(&v, "listing_status", r.ListingStatus, r.ListingStatuses, ListingStatuses)
+	page(&v, r.Start, r.Limit)
+	oneOf(&v, "sort", r.Sort, CryptocurrencyMapSortFields)
+	allOf(&v, "aux", r.Aux, r.AuxFields, CryptocurrencyMapAuxFields)
+
+	// symbol lookups ignore every other option
+	if r.Symbol != "" || len(r.Symbols) > 0 {
+		ignored := []param{
+			{"listing_status", r.ListingStatus != "" || len(r.ListingStatuses) > 0},
+			{"start", r.Start != 0},
+			{"limit", r.Limit != 0},
+			{"sort", r.Sort != ""},
+		}
+		for _, p := range ignored {
+			if p.set {
+				v.add(p.name, "", "ignored when symbol is set")
+			}
+		}
+	}
+	return v.err()
+}
+
+func (r *CryptocurrencyListingsHistoricalRequest) Validate() error {
+	var v validator
+	exclusive(&v, "date", r.Date, r.Day)
+	if r.Date == "" && r.Day.IsZero() {
+		v.add("date", "", "is required")
+	}
+	page(&v, r.Start, r.Limit)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	oneOf(&v, "sort", r.Sort, CryptocurrencyListingsHistoricalSortFields)
+	oneOf(&v, "sort_dir", r.SortDir, SortDirections)
+	oneOf(&v, "cryptocurrency_type", r.CryptocurrencyType, CryptocurrencyTypes)
+	allOf(&v, "aux", r.Aux, r.AuxFields, CryptocurrencyListingsHistoricalAuxFields)
+	return v.err()
+}
+
+func (r *CryptocurrencyListingsLatestRequest) Validate() error {
+	var v validator
+	page(&v, r.Start, r.Limit)
+	minMax(&v, "price", r.PriceMin, r.PriceMax)
+	minMax(&v, "market_cap", r.MarketCapMin, r.MarketCapMax)
+	minMax(&v, "volume_24h", r.Volume24HMin, r.Volume24HMax)
+	minMax(&v, "circulating_supply", r.CirculatingSupplyMin, r.CirculatingSupplyMax)
+	minMax(&v, "percent_change_24h", r.PercentChange24HMin, r.PercentChange24HMax)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	oneOf(&v, "sort", r.Sort, CryptocurrencyListingsLatestSortFields)
+	oneOf(&v, "sort_dir", r.SortDir, SortDirections)
+	oneOf(&v, "cryptocurrency_type", r.CryptocurrencyType, CryptocurrencyTypes)
+	oneOf(&v, "tag", r.Tag, ListingTags)
+	allOf(&v, "aux", r.Aux, r.AuxFields, CryptocurrencyListingsLatestAuxFields)
+	return v.err()
+}
+
+func (r *CryptocurrencyQuotesLatestRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	allOf(&v, "aux", r.Aux, r.AuxFields, CryptocurrencyQuotesLatestAuxFields)
+	return v.err()
+}
+
+func (r *CryptocurrencyOHLCVLatestRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	return v.err()
+}
+
+func (r *CryptocurrencyOHLCVHistoricalRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	oneOf(&v, "time_period", r.TimePeriod, OHLCVHistoricalTimePeriods)
+	timeRange(&v, r.TimeStart, r.TimeEnd, r.StartTime, r.EndTime)
+	count(&v, r.Count, r.TimeStart != "" || !r.StartTime.IsZero(), r.TimeEnd != "" || !r.EndTime.IsZero())
+	oneOf(&v, "interval", r.Interval, OHLCVHistoricalIntervals)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	return v.err()
+}
+
+func (r *CryptocurrencyPricePerformanceStatsRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	allOf(&v, "time_period", r.TimePeriod, r.TimePeriods, PricePerformanceTimePeriods)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	return v.err()
+}
+
+func (r *FiatMapRequest) Validate() error {
+	var v validator
+	page(&v, r.Start, r.Limit)
+	oneOf(&v, "sort", r.Sort, FiatMapSortFields)
+	return v.err()
+}
+
+func (r *ExchangeInfoRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	allOf(&v, "aux", r.Aux, r.AuxFields, ExchangeInfoAuxFields)
+	return v.err()
+}
+
+func (r *ExchangeIdMapRequest) Validate() error {
+	var v validator
+	allOf(&v, "listing_status", string(r.ListingStatus), r.ListingStatuses, ExchangeStatuses)
+	page(&v, r.Start, r.Limit)
+	oneOf(&v, "sort", r.Sort, ExchangeIdMapSortFields)
+	allOf(&v, "aux", r.Aux, r.AuxFields, ExchangeIdMapAuxFields)
+	return v.err()
+}
+
+func (r *GlobalMetricsQuotesLatestRequest) Validate() error {
+	var v validator
+	convert(&v, r.ConvertId, r.ConvertIds)
+	return v.err()
+}
+
+func (r *GlobalMetricsQuotesHistoricalRequest) Validate() error {
+	var v validator
+	timeRange(&v, r.TimeStart, r.TimeEnd, r.StartTime, r.EndTime)
+	count(&v, r.Count, r.TimeStart != "" || !r.StartTime.IsZero(), r.TimeEnd != "" || !r.EndTime.IsZero())
+	oneOf(&v, "interval", r.Interval, GlobalMetricsHistoricalIntervals)
+	convert(&v, r.ConvertId, r.ConvertIds)
+	allOf(&v, "aux", r.Aux, r.AuxFields, GlobalMetricsQuotesHistoricalAuxFields)
+	return v.err()
+}
+
+func (r *FCASListingsLatestRequest) Validate() error {
+	var v validator
+	page(&v, r.Start, r.Limit)
+	allOf(&v, "aux", r.Aux, r.AuxFields, FCASAuxFields)
+	return v.err()
+}
+
+func (r *FCASQuotesLatestRequest) Validate() error {
+	var v validator
+	exactlyOne(&v,
+		param{"id", r.Id != "" || len(r.Ids) > 0},
+		param{"slug", r.Slug != "" || len(r.Slugs) > 0},
+		param{"symbol", r.Symbol != "" || len(r.Symbols) > 0})
+	ids(&v, "id", r.Id, r.Ids)
+	allOf(&v, "aux", r.Aux, r.AuxFields, FCASAuxFields)
+	return v.err()
+}
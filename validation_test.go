@@ -0,0 +1,131 @@
+package coinmarketcap_go
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/drankou/coinmarketcap-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinmarketcapClient_RejectsUnsupportedOptions(t *testing.T) {
+	calls := 0
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	limiter := &countingLimiter{}
+	c.limiter = limiter
+
+	_, err := c.CryptocurrencyListingsLatest(&types.CryptocurrencyListingsLatestRequest{
+		Sort:      types.SortCmcRank,
+		SortDir:   types.SortDesc,
+		Aux:       "tags,urls",
+		AuxFields: []types.AuxField{types.AuxPlatform},
+	})
+
+	var validationErr *types.ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Len(t, validationErr.Errors, 2)
+		assert.Equal(t, "sort", validationErr.Errors[0].Field)
+		assert.Equal(t, "aux", validationErr.Errors[1].Field)
+		assert.Equal(t, "urls", validationErr.Errors[1].Value)
+	}
+	assert.True(t, errors.Is(err, types.ErrInvalidRequest))
+	assert.True(t, errors.Is(err, ErrInvalidParameter), "local validation must match the sentinel of a 400 response")
+	assert.Contains(t, err.Error(), "CryptocurrencyListingsLatest: ")
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 0, limiter.waits)
+}
+
+func TestCoinmarketcapClient_AcceptsSupportedOptions(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5m", r.URL.Query().Get("interval"))
+		assert.Equal(t, "btc_dominance,search_interval", r.URL.Query().Get("aux"))
+		w.Write([]byte(`{"status":{},"data":{"quotes":[]}}`))
+	})
+
+	_, err := c.GlobalMetricsQuotesHistorical(&types.GlobalMetricsQuotesHistoricalRequest{
+		Interval:  types.Interval5m,
+		AuxFields: []types.AuxField{types.AuxBTCDominance, types.AuxSearchInterval},
+	})
+	assert.NoError(t, err)
+
+	err = (&types.CryptocurrencyOHLCVHistoricalRequest{Interval: types.Interval5m}).Validate()
+	assert.True(t, errors.Is(err, types.ErrInvalidRequest))
+}
+
+func TestCoinmarketcapClient_TimeParameters(t *testing.T) {
+	c, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1566172800", r.URL.Query().Get("time_start"))
+		assert.Equal(t, "1566259200", r.URL.Query().Get("time_end"))
+		assert.Equal(t, "1d", r.URL.Query().Get("interval"))
+		w.Write([]byte(`{"status":{},"data":{}}`))
+	})
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	_, err := c.CryptocurrencyOHLCVHistorical(&types.CryptocurrencyOHLCVHistoricalRequest{
+		Symbol:    "BTC",
+		StartTime: time.Date(2019, 8, 19, 2, 0, 0, 0, berlin),
+		EndTime:   time.Date(2019, 8, 20, 0, 0, 0, 0, time.UTC),
+		Interval:  types.DurationInterval(24 * time.Hour),
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidate_TimeRange(t *testing.T) {
+	start := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	err := (&types.GlobalMetricsQuotesHistoricalRequest{StartTime: start, EndTime: start.Add(-time.Hour)}).Validate()
+	var validationErr *types.ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, "time_end", validationErr.Errors[0].Field)
+	}
+
+	err = (&types.GlobalMetricsQuotesHistoricalRequest{TimeStart: "2020-01-01", StartTime: start}).Validate()
+	assert.True(t, errors.Is(err, types.ErrInvalidRequest))
+
+	err = (&types.CryptocurrencyListingsHistoricalRequest{Day: start}).Validate()
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.Interval5m, types.DurationInterval(5*time.Minute))
+	assert.Equal(t, types.Interval12h, types.DurationInterval(12*time.Hour))
+	assert.Equal(t, types.Interval7d, types.DurationInterval(7*24*time.Hour))
+	assert.Equal(t, types.Interval("1m30s"), types.DurationInterval(90*time.Second))
+}
+
+func TestValidate_RequestConstraints(t *testing.T) {
+	fields := func(err error) []string {
+		var validationErr *types.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil
+		}
+		var names []string
+		for _, fieldErr := range validationErr.Errors {
+			names = append(names, fieldErr.Field)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"id"}, fields((&types.CryptocurrencyQuotesLatestRequest{}).Validate()))
+	assert.Equal(t, []string{"symbol"}, fields((&types.CryptocurrencyInfoRequest{Ids: []int{1}, Symbol: "BTC"}).Validate()))
+	assert.Equal(t, []string{"id", "id"}, fields((&types.CryptocurrencyInfoRequest{Id: "1,BTC", Ids: []int{0}}).Validate()))
+	assert.Equal(t, []string{"limit"}, fields((&types.CryptocurrencyListingsLatestRequest{Limit: 5001}).Validate()))
+	assert.Equal(t, []string{"start"}, fields((&types.FiatMapRequest{Start: -1}).Validate()))
+	assert.Equal(t, []string{"price_max"}, fields((&types.CryptocurrencyListingsLatestRequest{PriceMin: 2, PriceMax: 1}).Validate()))
+	assert.Equal(t, []string{"start", "sort"}, fields((&types.CryptocurrencyMapRequest{Symbol: "BTC", Start: 10, Sort: types.SortId}).Validate()))
+	assert.Equal(t, []string{"date"}, fields((&types.CryptocurrencyListingsHistoricalRequest{}).Validate()))
+	assert.Equal(t, []string{"count"}, fields((&types.CryptocurrencyOHLCVHistoricalRequest{
+		Symbol:    "BTC",
+		TimeStart: "2019-08-19",
+		TimeEnd:   "2019-08-20",
+		Count:     10,
+	}).Validate()))
+
+	assert.NoError(t, (&types.CryptocurrencyOHLCVHistoricalRequest{Symbol: "BTC", TimeStart: "2019-08-19", Count: 10}).Validate())
+	assert.NoError(t, (&types.CryptocurrencyMapRequest{ListingStatus: "active,inactive", Limit: 5000}).Validate())
+
+	err := (&types.ExchangeInfoRequest{}).Validate()
+	assert.EqualError(t, err, "coinmarketcap: invalid request: id: one of id, slug is required")
+}